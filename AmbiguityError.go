@@ -14,6 +14,7 @@ type AmbiguityError[ReadT any, ExpectT any] struct {
 	StartsAt *Packet[ReadT]
 	FormatPacket func(*Packet[ReadT]) string
 	Choices []AmbiguityChoice[ReadT, ExpectT]
+	Related []RelatedPacket[ReadT]
 }
 
 func(err *AmbiguityError[ReadT, ExpectT]) Start() *Packet[ReadT] {
@@ -42,6 +43,14 @@ func(err *AmbiguityError[ReadT, ExpectT]) SubErrors() []ParseError[ReadT, Expect
 	return nil
 }
 
+func(err *AmbiguityError[ReadT, ExpectT]) OfferRelated(related RelatedPacket[ReadT]) {
+	err.Related = append(err.Related, related)
+}
+
+func(err *AmbiguityError[ReadT, ExpectT]) RelatedPackets() []RelatedPacket[ReadT] {
+	return err.Related
+}
+
 func(err *AmbiguityError[ReadT, ExpectT]) Error() string {
 	var builder strings.Builder
 	builder.WriteString("Ambiguity in ")
@@ -50,10 +59,14 @@ func(err *AmbiguityError[ReadT, ExpectT]) Error() string {
 	} else {
 		builder.WriteString(err.Structure)
 	}
+	formatPacket := err.FormatPacket
+	if formatPacket == nil {
+		formatPacket = FormatPacket[ReadT]
+	}
 	var rendition string
 	var haveStartingAt bool
-	if err.StartsAt != nil && err.FormatPacket != nil {
-		rendition = err.FormatPacket(err.StartsAt)
+	if err.StartsAt != nil {
+		rendition = formatPacket(err.StartsAt)
 		if len(rendition) > 0 {
 			builder.WriteString(" starting at ")
 			builder.WriteString(rendition)
@@ -76,8 +89,8 @@ func(err *AmbiguityError[ReadT, ExpectT]) Error() string {
 			}
 		}
 	}
-	if allSamePacket && err.FormatPacket != nil {
-		rendition = err.FormatPacket(samePacket)
+	if allSamePacket {
+		rendition = formatPacket(samePacket)
 		if len(rendition) > 0 {
 			if haveStartingAt {
 				builder.WriteString(" and")
@@ -99,8 +112,8 @@ func(err *AmbiguityError[ReadT, ExpectT]) Error() string {
 			} else {
 				builder.WriteString("something")
 			}
-			if !allSamePacket && choice.EndBefore != nil && err.FormatPacket != nil {
-				rendition = err.FormatPacket(choice.EndBefore)
+			if !allSamePacket && choice.EndBefore != nil {
+				rendition = formatPacket(choice.EndBefore)
 				if len(rendition) > 0 {
 					builder.WriteString(" ending before ")
 					builder.WriteString(rendition)
@@ -108,6 +121,7 @@ func(err *AmbiguityError[ReadT, ExpectT]) Error() string {
 			}
 		}
 	}
+	writeRelated(&builder, err.Related)
 	return builder.String()
 }
 
@@ -2,36 +2,147 @@ package gorecdesc
 
 import (
 	"io"
+	"sort"
 	"sync"
 )
 
 type Cookie uint64
 
-type channelPair[ReadT any] struct {
-	packetChannel chan *Packet[ReadT]
+type subscription[ReadT any] struct {
+	readerID uint64
+	packetChannel chan []*Packet[ReadT]
 	ackChannel chan Acknowledgement
+	owedAcks int
 }
 
-type queuedChannel struct {
+type queuedChannel[ReadT any] struct {
 	cookie Cookie
-	ackChannel chan Acknowledgement
+	subscription *subscription[ReadT]
+}
+
+type DispatcherStats struct {
+	LiveSubscriptions int
+	LiveReaderIDs []uint64
+	Subscriptions uint64
+	PacketsSent uint64
+	PeakFanOut int
 }
 
 type Dispatcher[ReadT any] struct {
 	nextCookie Cookie
-	channels map[Cookie]channelPair[ReadT]
+	channels map[Cookie]*subscription[ReadT]
 	nextOffset uint64
+	batchSize uint
+	pending []*Packet[ReadT]
+	inFlight bool
+	done chan struct{}
+	closed bool
+	subscriptionCount uint64
+	packetsSent uint64
+	peakFanOut int
 	mapLock sync.Mutex
 	sendLock sync.Mutex
+	closeLock sync.Mutex
+	statsLock sync.Mutex
+}
+
+func(disp *Dispatcher[ReadT]) doneChannel() <-chan struct{} {
+	disp.closeLock.Lock()
+	defer disp.closeLock.Unlock()
+	if disp.done == nil {
+		disp.done = make(chan struct{})
+	}
+	return disp.done
+}
+
+func(disp *Dispatcher[ReadT]) IsClosed() bool {
+	disp.closeLock.Lock()
+	defer disp.closeLock.Unlock()
+	return disp.closed
+}
+
+func(disp *Dispatcher[ReadT]) Close() {
+	disp.closeLock.Lock()
+	if disp.closed {
+		disp.closeLock.Unlock()
+		return
+	}
+	disp.closed = true
+	if disp.done == nil {
+		disp.done = make(chan struct{})
+	}
+	close(disp.done)
+	disp.closeLock.Unlock()
+	// wait for a blocked delivery to give up
+	disp.sendLock.Lock()
+	disp.pending = nil
+	disp.sendLock.Unlock()
+	disp.mapLock.Lock()
+	if debugOn && len(disp.channels) > 0 {
+		debugf(
+			"[Dispatcher] Closing with Readers %v still subscribed\n",
+			liveReaderIDs(disp.channels),
+		)
+	}
+	disp.channels = nil
+	disp.mapLock.Unlock()
+}
+
+func(disp *Dispatcher[ReadT]) Stats() DispatcherStats {
+	disp.mapLock.Lock()
+	stats := DispatcherStats {
+		LiveSubscriptions: len(disp.channels),
+		LiveReaderIDs: liveReaderIDs(disp.channels),
+	}
+	disp.mapLock.Unlock()
+	disp.statsLock.Lock()
+	stats.Subscriptions = disp.subscriptionCount
+	stats.PacketsSent = disp.packetsSent
+	stats.PeakFanOut = disp.peakFanOut
+	disp.statsLock.Unlock()
+	return stats
+}
+
+func liveReaderIDs[ReadT any](channels map[Cookie]*subscription[ReadT]) []uint64 {
+	ids := make([]uint64, 0, len(channels))
+	for _, sub := range channels {
+		ids = append(ids, sub.readerID)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+	return ids
+}
+
+func(disp *Dispatcher[ReadT]) BatchSize() uint {
+	disp.sendLock.Lock()
+	defer disp.sendLock.Unlock()
+	if disp.batchSize == 0 {
+		return 1
+	}
+	return disp.batchSize
+}
+
+func(disp *Dispatcher[ReadT]) SetBatchSize(size uint) {
+	disp.sendLock.Lock()
+	disp.batchSize = size
+	if len(disp.pending) > 0 && uint(len(disp.pending)) >= size {
+		disp.deliver()
+	}
+	disp.sendLock.Unlock()
 }
 
 func(disp *Dispatcher[ReadT]) Send(item ReadT, eof bool) {
 	if debugOn {
 		debugf("[Dispatcher] Entering send for %v (eof = %v) with %d channels\n", item, eof, len(disp.channels))
 	}
+	if disp.IsClosed() {
+		if debugOn {
+			debugf("[Dispatcher] Dropping %v (eof = %v) since the Dispatcher is closed\n", item, eof)
+		}
+		return
+	}
 	disp.sendLock.Lock()
-	// send packets
-	disp.mapLock.Lock()
 	packet := &Packet[ReadT] {
 		Offset: disp.nextOffset,
 		Item: item,
@@ -41,19 +152,68 @@ func(disp *Dispatcher[ReadT]) Send(item ReadT, eof bool) {
 		debugf("[Dispatcher] Packet offset in send for %v (eof = %v) is %d\n", item, eof, packet.Offset)
 	}
 	disp.nextOffset++
+	disp.pending = append(disp.pending, packet)
+	disp.statsLock.Lock()
+	disp.packetsSent++
+	disp.statsLock.Unlock()
+	if eof || uint(len(disp.pending)) >= disp.batchSize {
+		disp.deliver()
+	}
+	disp.sendLock.Unlock()
+	if debugOn {
+		debugf(
+			"[Dispatcher] Leaving send for %v (eof = %v, offset = %d) with %d channels\n",
+			item,
+			eof,
+			packet.Offset,
+			len(disp.channels),
+		)
+	}
+}
+
+func(disp *Dispatcher[ReadT]) Flush() {
+	disp.sendLock.Lock()
+	if len(disp.pending) > 0 {
+		disp.deliver()
+	}
+	disp.sendLock.Unlock()
+}
+
+func(disp *Dispatcher[ReadT]) deliver() {
+	batch := disp.pending
+	disp.pending = nil
+	done := disp.doneChannel()
+	// send batch
+	disp.mapLock.Lock()
 	if disp.channels == nil {
-		disp.channels = make(map[Cookie]channelPair[ReadT])
+		disp.channels = make(map[Cookie]*subscription[ReadT])
 	}
-	channelQueue := make([]queuedChannel, len(disp.channels))
+	disp.inFlight = true
+	disp.statsLock.Lock()
+	if len(disp.channels) > disp.peakFanOut {
+		disp.peakFanOut = len(disp.channels)
+	}
+	disp.statsLock.Unlock()
+	channelQueue := make([]queuedChannel[ReadT], len(disp.channels))
 	index := 0
-	for cookie, pair := range disp.channels {
+	for cookie, sub := range disp.channels {
 		if debugOn {
-			debugf("[Dispatcher] Sending packet %d to Reader with cookie %d\n", packet.Offset, cookie)
+			debugf(
+				"[Dispatcher] Sending batch of %d packets starting at %d to Reader with cookie %d\n",
+				len(batch),
+				batch[0].Offset,
+				cookie,
+			)
+		}
+		select {
+			case sub.packetChannel <- batch:
+			case <-done:
+				disp.abortDelivery()
+				return
 		}
-		pair.packetChannel <- packet
-		channelQueue[index] = queuedChannel {
+		channelQueue[index] = queuedChannel[ReadT] {
 			cookie: cookie,
-			ackChannel: pair.ackChannel,
+			subscription: sub,
 		}
 		index++
 	}
@@ -62,21 +222,49 @@ func(disp *Dispatcher[ReadT]) Send(item ReadT, eof bool) {
 	toUnsubscribe := make([]Cookie, len(channelQueue))
 	unsubscribeCount := 0
 	for _, queued := range channelQueue {
-		if debugOn {
-			debugf(
-				"[Dispatcher] Awaiting ack to packet %d from Reader with cookie %d\n",
-				packet.Offset,
-				queued.cookie,
-			)
+		sub := queued.subscription
+		ack := ACK_KEEP_SUBSCRIPTION
+		for sub.owedAcks > 0 && ack == ACK_KEEP_SUBSCRIPTION {
+			// the Reader was split off while the previous batch was in flight
+			select {
+				case ack = <-sub.ackChannel:
+				case <-done:
+					disp.mapLock.Lock()
+					disp.abortDelivery()
+					return
+			}
+			sub.owedAcks--
+			if debugOn {
+				debugf(
+					"[Dispatcher] Owed ack from Reader with cookie %d was %s\n",
+					queued.cookie,
+					debugAck(ack),
+				)
+			}
 		}
-		ack := <-queued.ackChannel
-		if debugOn {
-			debugf(
-				"[Dispatcher] Ack to packet %d from Reader with cookie %d was %s\n",
-				packet.Offset,
-				queued.cookie,
-				debugAck(ack),
-			)
+		if ack == ACK_KEEP_SUBSCRIPTION {
+			if debugOn {
+				debugf(
+					"[Dispatcher] Awaiting ack to batch starting at %d from Reader with cookie %d\n",
+					batch[0].Offset,
+					queued.cookie,
+				)
+			}
+			select {
+				case ack = <-sub.ackChannel:
+				case <-done:
+					disp.mapLock.Lock()
+					disp.abortDelivery()
+					return
+			}
+			if debugOn {
+				debugf(
+					"[Dispatcher] Ack to batch starting at %d from Reader with cookie %d was %s\n",
+					batch[0].Offset,
+					queued.cookie,
+					debugAck(ack),
+				)
+			}
 		}
 		if ack != ACK_KEEP_SUBSCRIPTION {
 			toUnsubscribe[unsubscribeCount] = queued.cookie
@@ -84,68 +272,103 @@ func(disp *Dispatcher[ReadT]) Send(item ReadT, eof bool) {
 		}
 	}
 	// process unsubscribes
-	if unsubscribeCount > 0 {
-		disp.mapLock.Lock()
-		for index = 0; index < unsubscribeCount; index++ {
-			if debugOn {
-				debugf(
-					"[Dispatcher] Unsubscribing Reader with cookie %d in response to ack to packet %d\n",
-					toUnsubscribe[index],
-					packet.Offset,
-				)
-			}
-			delete(disp.channels, toUnsubscribe[index])
+	disp.mapLock.Lock()
+	for index = 0; index < unsubscribeCount; index++ {
+		if debugOn {
+			debugf(
+				"[Dispatcher] Unsubscribing Reader with cookie %d in response to ack to batch starting at %d\n",
+				toUnsubscribe[index],
+				batch[0].Offset,
+			)
 		}
-		disp.mapLock.Unlock()
+		delete(disp.channels, toUnsubscribe[index])
 	}
-	disp.sendLock.Unlock()
-	if debugOn {
+	disp.inFlight = false
+	if debugOn && batch[len(batch) - 1].EOF && len(disp.channels) > 0 {
 		debugf(
-			"[Dispatcher] Leaving send for %v (eof = %v, offset = %d) with %d channels\n",
-			item,
-			eof,
-			packet.Offset,
-			len(disp.channels),
+			"[Dispatcher] Readers %v are still subscribed after EOF and never unsubscribed\n",
+			liveReaderIDs(disp.channels),
 		)
 	}
+	disp.mapLock.Unlock()
 }
 
-func(disp *Dispatcher[ReadT]) Subscribe() *Reader[ReadT] {
-	disp.mapLock.Lock()
-	packetChannel := make(chan *Packet[ReadT])
-	ackChannel := make(chan Acknowledgement)
-	cookie := disp.nextCookie
-	disp.channels[cookie] = channelPair[ReadT] {
-		packetChannel: packetChannel,
-		ackChannel: ackChannel,
+func(disp *Dispatcher[ReadT]) abortDelivery() {
+	// mapLock is held by the caller
+	if debugOn {
+		debugf(
+			"[Dispatcher] Aborting delivery since the Dispatcher was closed with Readers %v still subscribed\n",
+			liveReaderIDs(disp.channels),
+		)
 	}
-	disp.nextCookie++
+	disp.inFlight = false
 	disp.mapLock.Unlock()
+}
+
+func(disp *Dispatcher[ReadT]) Subscribe() *Reader[ReadT] {
+	return disp.subscribe(false)
+}
+
+func(disp *Dispatcher[ReadT]) subscribe(owesAck bool) *Reader[ReadT] {
+	packetChannel := make(chan []*Packet[ReadT], 1)
+	ackChannel := make(chan Acknowledgement, 2)
 	reader := &Reader[ReadT] {
 		id: NewReaderID(),
 		dispatcher: disp,
 		packetChannel: packetChannel,
 		ackChannel: ackChannel,
+		done: disp.doneChannel(),
+	}
+	if disp.IsClosed() {
+		if debugOn {
+			debugf("[Dispatcher] Issuing new Reader %d without subscription since the Dispatcher is closed\n", reader.id)
+		}
+		return reader
 	}
+	disp.mapLock.Lock()
+	if disp.channels == nil {
+		disp.channels = make(map[Cookie]*subscription[ReadT])
+	}
+	sub := &subscription[ReadT] {
+		readerID: reader.id,
+		packetChannel: packetChannel,
+		ackChannel: ackChannel,
+	}
+	if owesAck && disp.inFlight {
+		sub.owedAcks = 1
+	}
+	cookie := disp.nextCookie
+	disp.channels[cookie] = sub
+	disp.nextCookie++
+	disp.mapLock.Unlock()
+	disp.statsLock.Lock()
+	disp.subscriptionCount++
+	disp.statsLock.Unlock()
 	if debugOn {
-		debugf("[Dispatcher] Issuing new Reader %d with cookie %d\n", reader.id, cookie)
+		debugf(
+			"[Dispatcher] Issuing new Reader %d with cookie %d (owed acks = %d)\n",
+			reader.id,
+			cookie,
+			sub.owedAcks,
+		)
 	}
 	return reader
 }
 
 func SendRunes(disp *Dispatcher[Locatable[rune]], reader io.RuneReader, location Location) error {
+	bomSize, err := skipByteOrderMark(reader)
+	if err != nil {
+		return err
+	}
+	location.ByteOffset += uint64(bomSize)
 	for {
-		r, _, err := reader.ReadRune()
+		r, size, err := reader.ReadRune()
 		if err == nil {
 			disp.Send(Locatable[rune] {
 				Symbol: r,
 				Location: location,
 			}, false)
-			if r == '\n' {
-				location.NextLine()
-			} else {
-				location.NextColumn()
-			}
+			location.NextRune(r, size)
 		} else if err == io.EOF {
 			disp.Send(Locatable[rune] {
 				Symbol: '\x00',
@@ -153,12 +376,24 @@ func SendRunes(disp *Dispatcher[Locatable[rune]], reader io.RuneReader, location
 			}, true)
 			break
 		} else {
+			if encodingError, ok := err.(*EncodingError); ok {
+				encodingError.Location = location
+			}
 			return err
 		}
 	}
 	return nil
 }
 
+func SendDecoded(
+	disp *Dispatcher[Locatable[rune]],
+	reader io.Reader,
+	encoding Encoding,
+	location Location,
+) error {
+	return SendRunes(disp, NewRuneDecoder(reader, encoding), location)
+}
+
 func SendBytes(disp *Dispatcher[Locatable[byte]], reader io.Reader, location Location) error {
 	buffer := make([]byte, 128)
 	for {
@@ -168,11 +403,7 @@ func SendBytes(disp *Dispatcher[Locatable[byte]], reader io.Reader, location Loc
 				Symbol: buffer[i],
 				Location: location,
 			}, false)
-			if buffer[i] == byte('\n') {
-				location.NextLine()
-			} else {
-				location.NextColumn()
-			}
+			location.NextByte(buffer[i])
 		}
 		if err != nil {
 			if err != io.EOF {
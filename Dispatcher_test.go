@@ -0,0 +1,225 @@
+package gorecdesc
+
+import (
+	"strings"
+	tst "testing"
+	. "github.com/UncleSniper/gotest"
+)
+
+type testRune = Locatable[rune]
+
+func testRuneToken(r rune) Rule[testRune, *Packet[testRune], string] {
+	return SingleToken[testRune, string](
+		nil,
+		string(r),
+		nil,
+		TokenPredicate(func(item testRune) bool {
+			return item.Symbol == r
+		}),
+	)
+}
+
+func testCount[PieceT any](count int, piece PieceT) int {
+	return count + 1
+}
+
+var testBatchSizes = []uint {1, 2, 3, 5}
+
+func forEachTestBatchSize(body func(batchSize uint)) {
+	for _, batchSize := range testBatchSizes {
+		body(batchSize)
+	}
+}
+
+func parseTestInput[OutT any](
+	rule Rule[testRune, OutT, string],
+	input string,
+	batchSize uint,
+) (*Result[testRune, OutT, string], DispatcherStats) {
+	var disp Dispatcher[testRune]
+	disp.SetBatchSize(batchSize)
+	reader := disp.Subscribe()
+	resultChannel := make(chan *Result[testRune, OutT, string])
+	go func() {
+		reader.Next()
+		result := RunRule(rule, reader)
+		result.Reader.AcknowledgeOnChannel(ACK_UNSUBSCRIBE_ON_SUCCESS)
+		resultChannel <- result
+	}()
+	sent := make(chan error)
+	go func() {
+		sent <- SendRunes(&disp, strings.NewReader(input), StartOfFile("test"))
+	}()
+	result := <-resultChannel
+	disp.Close()
+	<-sent
+	return result, disp.Stats()
+}
+
+func TestDispatcherBatchedSequence(t *tst.T) {
+	c := Use(t)
+	rule := Sequence[testRune, int, *Packet[testRune], string](
+		The(0),
+		testCount[*Packet[testRune]],
+		testRuneToken('a'),
+		testRuneToken('b'),
+		testRuneToken('c'),
+	)
+	forEachTestBatchSize(func(batchSize uint) {
+		result, stats := parseTestInput(rule, "abc", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(true))
+		AssertThat(c, result.Result).Is(EqualTo(3))
+		AssertThat(c, result.Offset).Is(EqualTo[uint64](3))
+		AssertThat(c, stats.PacketsSent).Is(EqualTo[uint64](4))
+		AssertThat(c, stats.LiveSubscriptions).Is(EqualTo(0))
+	})
+}
+
+func TestDispatcherBatchedChoiceInRepetition(t *tst.T) {
+	c := Use(t)
+	long := Sequence[testRune, int, *Packet[testRune], string](
+		The(0),
+		testCount[*Packet[testRune]],
+		testRuneToken('a'),
+		testRuneToken('b'),
+	)
+	short := Sequence[testRune, int, *Packet[testRune], string](
+		The(0),
+		testCount[*Packet[testRune]],
+		testRuneToken('c'),
+	)
+	item := Choice[testRune, int, string]("item", nil, "", nil, nil, nil, long, short)
+	rule := Repetition[testRune, int, int, *Packet[testRune], string](
+		nil,
+		The(0),
+		func(sum int, separator *Packet[testRune], item int) int {
+			return sum * 10 + item
+		},
+		"item",
+		nil,
+		item,
+		testRuneToken(','),
+		1,
+		10,
+		false,
+	)
+	forEachTestBatchSize(func(batchSize uint) {
+		result, stats := parseTestInput(rule, "ab,c,ab", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(true))
+		AssertThat(c, result.Result).Is(EqualTo(212))
+		AssertThat(c, result.Offset).Is(EqualTo[uint64](7))
+		AssertThat(c, stats.PeakFanOut).Is(GreaterThan(1))
+	})
+}
+
+func TestDispatcherCloseReleasesSend(t *tst.T) {
+	c := Use(t)
+	var disp Dispatcher[testRune]
+	reader := disp.Subscribe()
+	sent := make(chan error)
+	go func() {
+		sent <- SendRunes(&disp, strings.NewReader("abc"), StartOfFile("test"))
+	}()
+	// take the first packet, but never acknowledge it
+	reader.Next()
+	stats := disp.Stats()
+	AssertThat(c, stats.LiveSubscriptions).Is(EqualTo(1))
+	AssertThat(c, stats.LiveReaderIDs).Is(ContainAll([]uint64 {reader.ID()}))
+	disp.Close()
+	AssertThat(c, <-sent).Is(ZeroValue[error]())
+	AssertThat(c, disp.Stats().LiveSubscriptions).Is(EqualTo(0))
+}
+
+func TestDispatcherCloseEndsReader(t *tst.T) {
+	c := Use(t)
+	var disp Dispatcher[testRune]
+	reader := disp.Subscribe()
+	packets := make(chan *Packet[testRune])
+	go func() {
+		packets <- reader.Next()
+	}()
+	disp.Close()
+	packet := <-packets
+	AssertThat(c, packet.EOF).Is(EqualTo(true))
+	AssertThat(c, packet.Offset).Is(EqualTo[uint64](0))
+	disp.Send(Locatable[rune] {Symbol: 'a'}, false)
+	AssertThat(c, disp.Stats().PacketsSent).Is(EqualTo[uint64](0))
+}
+
+func TestDispatcherBatchSize(t *tst.T) {
+	c := Use(t)
+	var disp Dispatcher[testRune]
+	AssertThat(c, disp.BatchSize()).Is(EqualTo[uint](1))
+	disp.SetBatchSize(4)
+	AssertThat(c, disp.BatchSize()).Is(EqualTo[uint](4))
+}
+
+func TestDispatcherFlushDeliversPartialBatch(t *tst.T) {
+	c := Use(t)
+	var disp Dispatcher[testRune]
+	disp.SetBatchSize(3)
+	reader := disp.Subscribe()
+	// a partial batch stays queued
+	disp.Send(Locatable[rune] {Symbol: 'a'}, false)
+	disp.Send(Locatable[rune] {Symbol: 'b'}, false)
+	flushed := make(chan struct{})
+	go func() {
+		disp.Flush()
+		close(flushed)
+	}()
+	AssertThat(c, reader.Next().Item.Symbol).Is(EqualTo('a'))
+	AssertThat(c, len(reader.batch)).Is(EqualTo(2))
+	AssertThat(c, reader.Next().Item.Symbol).Is(EqualTo('b'))
+	reader.Acknowledge(ACK_KEEP_SUBSCRIPTION)
+	<-flushed
+	// lowering the batch size delivers what has queued up
+	disp.Send(Locatable[rune] {Symbol: 'c'}, false)
+	disp.Send(Locatable[rune] {Symbol: 'd'}, false)
+	lowered := make(chan struct{})
+	go func() {
+		disp.SetBatchSize(2)
+		close(lowered)
+	}()
+	AssertThat(c, reader.Next().Item.Symbol).Is(EqualTo('c'))
+	AssertThat(c, reader.Next().Item.Symbol).Is(EqualTo('d'))
+	reader.Acknowledge(ACK_KEEP_SUBSCRIPTION)
+	<-lowered
+	sent := make(chan struct{})
+	go func() {
+		disp.Send(Locatable[rune] {}, true)
+		close(sent)
+	}()
+	AssertThat(c, reader.Next().EOF).Is(EqualTo(true))
+	reader.AcknowledgeOnChannel(ACK_UNSUBSCRIBE_ON_SUCCESS)
+	<-sent
+	stats := disp.Stats()
+	AssertThat(c, stats.PacketsSent).Is(EqualTo[uint64](5))
+	AssertThat(c, stats.LiveSubscriptions).Is(EqualTo(0))
+}
+
+func TestDispatcherSplitMidBatch(t *tst.T) {
+	c := Use(t)
+	var disp Dispatcher[testRune]
+	disp.SetBatchSize(4)
+	reader := disp.Subscribe()
+	sent := make(chan error)
+	go func() {
+		sent <- SendRunes(&disp, strings.NewReader("abcdef"), StartOfFile("test"))
+	}()
+	reader.Next()
+	reader.Next()
+	// the split Reader starts inside the batch its parent still owes an ack for
+	split := reader.Split()
+	AssertThat(c, split.Current().Item.Symbol).Is(EqualTo('b'))
+	for _, current := range []*Reader[testRune] {reader, split} {
+		var builder strings.Builder
+		for packet := current.Current(); !packet.EOF; packet = current.Next() {
+			builder.WriteRune(packet.Item.Symbol)
+			current.Acknowledge(ACK_KEEP_SUBSCRIPTION)
+		}
+		AssertThat(c, builder.String()).Is(EqualTo("bcdef"))
+		current.AcknowledgeOnChannel(ACK_UNSUBSCRIBE_ON_SUCCESS)
+	}
+	AssertThat(c, <-sent).Is(ZeroValue[error]())
+	AssertThat(c, disp.Stats().LiveSubscriptions).Is(EqualTo(0))
+}
@@ -0,0 +1,35 @@
+package gorecdesc
+
+import (
+	"fmt"
+	"strings"
+)
+
+type EncodingError struct {
+	Encoding Encoding
+	Bytes []byte
+	ByteOffset uint64
+	Truncated bool
+	Location Location
+}
+
+func(err *EncodingError) Error() string {
+	var builder strings.Builder
+	if err.Truncated {
+		builder.WriteString("Truncated ")
+	} else {
+		builder.WriteString("Invalid ")
+	}
+	builder.WriteString(err.Encoding.String())
+	builder.WriteString(" byte sequence")
+	for _, b := range err.Bytes {
+		builder.WriteString(fmt.Sprintf(" 0x%02X", b))
+	}
+	if err.Location.isEmpty() {
+		builder.WriteString(fmt.Sprintf(" at byte offset %d", err.ByteOffset))
+	} else {
+		builder.WriteString(" at ")
+		builder.WriteString(err.Location.Format())
+	}
+	return builder.String()
+}
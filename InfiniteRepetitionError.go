@@ -8,6 +8,7 @@ type InfiniteRepetitionError[ReadT any, ExpectT any] struct {
 	Found *Packet[ReadT]
 	FormatFound func(*Packet[ReadT]) string
 	Structure string
+	Related []RelatedPacket[ReadT]
 }
 
 func(err *InfiniteRepetitionError[ReadT, ExpectT]) Start() *Packet[ReadT] {
@@ -36,6 +37,14 @@ func(err *InfiniteRepetitionError[ReadT, ExpectT]) SubErrors() []ParseError[Read
 	return nil
 }
 
+func(err *InfiniteRepetitionError[ReadT, ExpectT]) OfferRelated(related RelatedPacket[ReadT]) {
+	err.Related = append(err.Related, related)
+}
+
+func(err *InfiniteRepetitionError[ReadT, ExpectT]) RelatedPackets() []RelatedPacket[ReadT] {
+	return err.Related
+}
+
 func(err *InfiniteRepetitionError[ReadT, ExpectT]) Error() string {
 	var builder strings.Builder
 	builder.WriteString("Repetition")
@@ -43,14 +52,19 @@ func(err *InfiniteRepetitionError[ReadT, ExpectT]) Error() string {
 		builder.WriteString(" in ")
 		builder.WriteString(err.Structure)
 	}
-	if err.Found != nil && err.FormatFound != nil {
-		rendition := err.FormatFound(err.Found)
+	if err.Found != nil {
+		formatFound := err.FormatFound
+		if formatFound == nil {
+			formatFound = FormatPacket[ReadT]
+		}
+		rendition := formatFound(err.Found)
 		if len(rendition) > 0 {
 			builder.WriteString(" near ")
 			builder.WriteString(rendition)
 		}
 	}
 	builder.WriteString(" would be infinite: Iteration consumed no packets but did not fail, either")
+	writeRelated(&builder, err.Related)
 	return builder.String()
 }
 
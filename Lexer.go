@@ -0,0 +1,319 @@
+package gorecdesc
+
+import (
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type Token[KindT any] struct {
+	Kind KindT
+	Text string
+	End Location
+}
+
+func(token Token[KindT]) String() string {
+	return strconv.Quote(token.Text)
+}
+
+type LexerInput struct {
+	source io.RuneReader
+	buffer []byte
+	sizes []int
+	location Location
+	err error
+}
+
+func(input *LexerInput) Text() string {
+	return string(input.buffer)
+}
+
+func(input *LexerInput) Location() Location {
+	return input.location
+}
+
+func(input *LexerInput) Lookahead(length int) string {
+	for len(input.buffer) < length && input.readMore() {}
+	return string(input.buffer)
+}
+
+func(input *LexerInput) RuneReader() io.RuneReader {
+	return &lexerInputReader {
+		input: input,
+	}
+}
+
+func(input *LexerInput) readMore() bool {
+	if input.err != nil {
+		return false
+	}
+	r, size, err := input.source.ReadRune()
+	if err != nil {
+		input.err = err
+		return false
+	}
+	// the buffer holds UTF-8, but locations advance by what the rune took in the source
+	input.buffer = utf8.AppendRune(input.buffer, r)
+	input.sizes = append(input.sizes, size)
+	return true
+}
+
+func(input *LexerInput) advance(length int) {
+	runeIndex := 0
+	for offset := 0; offset < length; runeIndex++ {
+		r, size := utf8.DecodeRune(input.buffer[offset:])
+		input.location.NextRune(r, input.sizes[runeIndex])
+		offset += size
+	}
+	input.buffer = input.buffer[length:]
+	input.sizes = input.sizes[runeIndex:]
+}
+
+type lexerInputReader struct {
+	input *LexerInput
+	offset int
+	runeIndex int
+}
+
+func(reader *lexerInputReader) ReadRune() (rune, int, error) {
+	input := reader.input
+	for reader.offset >= len(input.buffer) {
+		if !input.readMore() {
+			return 0, 0, io.EOF
+		}
+	}
+	r, size := utf8.DecodeRune(input.buffer[reader.offset:])
+	reader.offset += size
+	reader.runeIndex++
+	return r, size, nil
+}
+
+func(reader *lexerInputReader) sourceSize() int {
+	return reader.input.sizes[reader.runeIndex - 1]
+}
+
+type LexMatcher interface {
+	MatchToken(input *LexerInput) int
+}
+
+type LexMatcherFunc func(*LexerInput) int
+
+func(matcher LexMatcherFunc) MatchToken(input *LexerInput) int {
+	return matcher(input)
+}
+
+type patternLex struct {
+	pattern *regexp.Regexp
+}
+
+func PatternLex(pattern string) (LexMatcher, error) {
+	compiled, err := regexp.Compile(`\A(?:` + pattern + `)`)
+	if err != nil {
+		return nil, err
+	}
+	compiled.Longest()
+	return &patternLex {
+		pattern: compiled,
+	}, nil
+}
+
+func MustPatternLex(pattern string) LexMatcher {
+	matcher, err := PatternLex(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return matcher
+}
+
+func(matcher *patternLex) MatchToken(input *LexerInput) int {
+	match := matcher.pattern.FindReaderIndex(input.RuneReader())
+	if match == nil {
+		return 0
+	}
+	return match[1]
+}
+
+type literalLex struct {
+	literal string
+}
+
+func LiteralLex(literal string) LexMatcher {
+	return &literalLex {
+		literal: literal,
+	}
+}
+
+func(matcher *literalLex) MatchToken(input *LexerInput) int {
+	if strings.HasPrefix(input.Lookahead(len(matcher.literal)), matcher.literal) {
+		return len(matcher.literal)
+	}
+	return 0
+}
+
+type ruleLex[OutT any, ExpectT any] struct {
+	rule Rule[Locatable[rune], OutT, ExpectT]
+}
+
+func RuleLex[OutT any, ExpectT any](rule Rule[Locatable[rune], OutT, ExpectT]) LexMatcher {
+	return &ruleLex[OutT, ExpectT] {
+		rule: rule,
+	}
+}
+
+func(matcher *ruleLex[OutT, ExpectT]) MatchToken(input *LexerInput) int {
+	if matcher.rule == nil {
+		return 0
+	}
+	var disp Dispatcher[Locatable[rune]]
+	reader := disp.Subscribe()
+	resultChannel := make(chan *Result[Locatable[rune], OutT, ExpectT], 1)
+	go func() {
+		reader.Next()
+		result := RunRule(matcher.rule, reader)
+		result.Reader.AcknowledgeOnChannel(ACK_UNSUBSCRIBE_ON_SUCCESS)
+		resultChannel <- result
+	}()
+	// feed runes only for as long as the rule is still reading them
+	source := input.RuneReader().(*lexerInputReader)
+	location := input.Location()
+	for disp.Stats().LiveSubscriptions > 0 {
+		r, _, err := source.ReadRune()
+		if err != nil {
+			disp.Send(Locatable[rune] {
+				Symbol: '\x00',
+				Location: location,
+			}, true)
+			break
+		}
+		disp.Send(Locatable[rune] {
+			Symbol: r,
+			Location: location,
+		}, false)
+		location.NextRune(r, source.sourceSize())
+	}
+	result := <-resultChannel
+	disp.Close()
+	if result.Error != nil {
+		return 0
+	}
+	length := 0
+	for runeCount := result.Offset; runeCount > 0 && length < len(input.buffer); runeCount-- {
+		_, size := utf8.DecodeRune(input.buffer[length:])
+		length += size
+	}
+	return length
+}
+
+type lexDefinition[KindT any] struct {
+	kind KindT
+	priority int
+	matcher LexMatcher
+	skip bool
+}
+
+type Lexer[KindT any] struct {
+	definitions []*lexDefinition[KindT]
+}
+
+func(lexer *Lexer[KindT]) Define(kind KindT, priority int, matcher LexMatcher) {
+	lexer.definitions = append(lexer.definitions, &lexDefinition[KindT] {
+		kind: kind,
+		priority: priority,
+		matcher: matcher,
+	})
+}
+
+func(lexer *Lexer[KindT]) DefineSkip(priority int, matcher LexMatcher) {
+	lexer.definitions = append(lexer.definitions, &lexDefinition[KindT] {
+		priority: priority,
+		matcher: matcher,
+		skip: true,
+	})
+}
+
+func(lexer *Lexer[KindT]) match(input *LexerInput) (*lexDefinition[KindT], int) {
+	var best *lexDefinition[KindT]
+	bestLength := 0
+	for _, definition := range lexer.definitions {
+		if definition.matcher == nil {
+			continue
+		}
+		length := definition.matcher.MatchToken(input)
+		if length <= 0 {
+			continue
+		}
+		if length > len(input.buffer) {
+			length = len(input.buffer)
+		}
+		// longest match wins, then priority, then definition order
+		if best == nil || length > bestLength || (length == bestLength && definition.priority > best.priority) {
+			best = definition
+			bestLength = length
+		}
+	}
+	return best, bestLength
+}
+
+func(lexer *Lexer[KindT]) Send(
+	disp *Dispatcher[Locatable[Token[KindT]]],
+	reader io.RuneReader,
+	location Location,
+) error {
+	input := &LexerInput {
+		source: reader,
+		location: location,
+	}
+	for len(input.Lookahead(1)) > 0 {
+		definition, length := lexer.match(input)
+		if definition == nil {
+			r, _ := utf8.DecodeRune(input.buffer)
+			if debugOn {
+				debugf("[Lexer] No token matches %q at %s\n", r, input.location.Format())
+			}
+			// the token stream ends here, so token-level Readers must not wait for more
+			lexer.sendEOF(disp, input.location)
+			return &LexicalError {
+				Found: r,
+				Location: input.location,
+			}
+		}
+		start := input.location
+		text := string(input.buffer[:length])
+		input.advance(length)
+		if debugOn {
+			debugf("[Lexer] Matched %q at %s (skip = %v)\n", text, start.Format(), definition.skip)
+		}
+		if definition.skip {
+			continue
+		}
+		disp.Send(Locatable[Token[KindT]] {
+			Symbol: Token[KindT] {
+				Kind: definition.kind,
+				Text: text,
+				End: input.location,
+			},
+			Location: start,
+		}, false)
+	}
+	lexer.sendEOF(disp, input.location)
+	if input.err != io.EOF {
+		if encodingError, ok := input.err.(*EncodingError); ok {
+			encodingError.Location = input.location
+		}
+		return input.err
+	}
+	return nil
+}
+
+func(lexer *Lexer[KindT]) sendEOF(disp *Dispatcher[Locatable[Token[KindT]]], location Location) {
+	disp.Send(Locatable[Token[KindT]] {
+		Symbol: Token[KindT] {
+			End: location,
+		},
+		Location: location,
+	}, true)
+}
+
+var _ LexMatcher = LexMatcherFunc(nil)
@@ -0,0 +1,211 @@
+package gorecdesc
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	tst "testing"
+	"time"
+	. "github.com/UncleSniper/gotest"
+)
+
+type testKind uint
+
+const (
+	testIdent testKind = iota + 1
+	testNumber
+	testIf
+	testArrow
+)
+
+func newTestLexer() *Lexer[testKind] {
+	lexer := &Lexer[testKind]{}
+	lexer.Define(testIdent, 0, MustPatternLex(`[a-z]+`))
+	lexer.Define(testNumber, 0, MustPatternLex(`[0-9]+`))
+	lexer.Define(testIf, 1, LiteralLex("if"))
+	lexer.Define(testArrow, 0, RuleLex(Sequence[testRune, int, *Packet[testRune], string](
+		The(0),
+		testCount[*Packet[testRune]],
+		testRuneToken('-'),
+		testRuneToken('>'),
+	)))
+	lexer.DefineSkip(0, MustPatternLex(`\s+`))
+	return lexer
+}
+
+func lexTestInput(lexer *Lexer[testKind], input string) ([]Locatable[Token[testKind]], error) {
+	var disp Dispatcher[Locatable[Token[testKind]]]
+	reader := disp.Subscribe()
+	tokens := make(chan []Locatable[Token[testKind]])
+	go func() {
+		var collected []Locatable[Token[testKind]]
+		for packet := reader.Next(); !packet.EOF; packet = reader.Next() {
+			collected = append(collected, packet.Item)
+			reader.Acknowledge(ACK_KEEP_SUBSCRIPTION)
+		}
+		reader.AcknowledgeOnChannel(ACK_UNSUBSCRIBE_ON_SUCCESS)
+		tokens <- collected
+	}()
+	err := lexer.Send(&disp, strings.NewReader(input), StartOfFile("test"))
+	disp.Close()
+	return <-tokens, err
+}
+
+func TestLexerLongestMatchAndPriority(t *tst.T) {
+	c := Use(t)
+	tokens, err := lexTestInput(newTestLexer(), "if iffy -> 42")
+	AssertThat(c, err).Is(ZeroValue[error]())
+	AssertThat(c, len(tokens)).Is(EqualTo(4))
+	AssertThat(c, tokens[0].Symbol.Kind).Is(EqualTo(testIf))
+	AssertThat(c, tokens[1].Symbol.Kind).Is(EqualTo(testIdent))
+	AssertThat(c, tokens[1].Symbol.Text).Is(EqualTo("iffy"))
+	AssertThat(c, tokens[1].Location.Column).Is(EqualTo[uint](4))
+	AssertThat(c, tokens[2].Symbol.Kind).Is(EqualTo(testArrow))
+	AssertThat(c, tokens[2].Symbol.End.Column).Is(EqualTo[uint](11))
+	AssertThat(c, tokens[3].Symbol.Kind).Is(EqualTo(testNumber))
+}
+
+func TestLexerError(t *tst.T) {
+	c := Use(t)
+	_, err := lexTestInput(newTestLexer(), "abc\n  ?")
+	AssertThat(c, err).Is(ErrorWithMessage("Unexpected character '?' at test:2:3"))
+}
+
+func TestLexerFeedsTokenGrammar(t *tst.T) {
+	c := Use(t)
+	type tokenT = Locatable[Token[testKind]]
+	kind := func(kind testKind) Rule[tokenT, *Packet[tokenT], string] {
+		return SingleToken[tokenT, string](nil, "token", nil, TokenPredicate(func(token tokenT) bool {
+			return token.Symbol.Kind == kind
+		}))
+	}
+	rule := Sequence[tokenT, int, *Packet[tokenT], string](
+		The(0),
+		testCount[*Packet[tokenT]],
+		kind(testIf),
+		kind(testIdent),
+		kind(testArrow),
+		kind(testNumber),
+	)
+	var disp Dispatcher[tokenT]
+	reader := disp.Subscribe()
+	resultChannel := make(chan *Result[tokenT, int, string])
+	go func() {
+		reader.Next()
+		result := RunRule(rule, reader)
+		result.Reader.AcknowledgeOnChannel(ACK_UNSUBSCRIBE_ON_SUCCESS)
+		resultChannel <- result
+	}()
+	go newTestLexer().Send(&disp, strings.NewReader("if x->1"), StartOfFile("test"))
+	result := <-resultChannel
+	disp.Close()
+	AssertThat(c, result.Error == nil).Is(EqualTo(true))
+	AssertThat(c, result.Result).Is(EqualTo(4))
+	AssertThat(c, result.Offset).Is(EqualTo[uint64](4))
+}
+
+func TestLexerStreams(t *tst.T) {
+	c := Use(t)
+	pipeReader, pipeWriter := io.Pipe()
+	var disp Dispatcher[Locatable[Token[testKind]]]
+	reader := disp.Subscribe()
+	sent := make(chan error)
+	go func() {
+		sent <- newTestLexer().Send(&disp, bufio.NewReader(pipeReader), StartOfFile("test"))
+	}()
+	tokens := make(chan *Packet[Locatable[Token[testKind]]])
+	go func() {
+		for packet := reader.Next(); ; packet = reader.Next() {
+			tokens <- packet
+			if packet.EOF {
+				break
+			}
+			reader.Acknowledge(ACK_KEEP_SUBSCRIPTION)
+		}
+		reader.AcknowledgeOnChannel(ACK_UNSUBSCRIBE_ON_SUCCESS)
+	}()
+	pipeWriter.Write([]byte("abc 42 "))
+	// the first token must arrive while the rest of the input is still outstanding
+	select {
+		case packet := <-tokens:
+			AssertThat(c, packet.Item.Symbol.Text).Is(EqualTo("abc"))
+		case <-time.After(5 * time.Second):
+			AssertThat(c, "token before end of input").Is(EqualTo("no token"))
+	}
+	pipeWriter.Write([]byte("x"))
+	pipeWriter.Close()
+	AssertThat(c, (<-tokens).Item.Symbol.Text).Is(EqualTo("42"))
+	AssertThat(c, (<-tokens).Item.Symbol.Text).Is(EqualTo("x"))
+	AssertThat(c, (<-tokens).EOF).Is(EqualTo(true))
+	AssertThat(c, <-sent).Is(ZeroValue[error]())
+}
+
+func TestLexerErrorEndsTokenStream(t *tst.T) {
+	c := Use(t)
+	type tokenT = Locatable[Token[testKind]]
+	rule := Many(nil, SingleToken[tokenT, string](nil, "token", nil, func(packet *Packet[tokenT]) bool {
+		return !packet.EOF
+	}))
+	var disp Dispatcher[tokenT]
+	reader := disp.Subscribe()
+	resultChannel := make(chan *Result[tokenT, []*Packet[tokenT], string])
+	go func() {
+		reader.Next()
+		result := RunRule(rule, reader)
+		result.Reader.AcknowledgeOnChannel(ACK_UNSUBSCRIBE_ON_SUCCESS)
+		resultChannel <- result
+	}()
+	err := newTestLexer().Send(&disp, strings.NewReader("if x ?"), StartOfFile("test"))
+	AssertThat(c, err).Is(ErrorWithMessage("Unexpected character '?' at test:1:6"))
+	// no Close: the parser must see the end of the token stream on its own
+	result := <-resultChannel
+	AssertThat(c, result.Error == nil).Is(EqualTo(true))
+	AssertThat(c, len(result.Result)).Is(EqualTo(2))
+	disp.Close()
+}
+
+type testCountingRuneReader struct {
+	reader io.RuneReader
+	count int
+}
+
+func(reader *testCountingRuneReader) ReadRune() (rune, int, error) {
+	reader.count++
+	return reader.reader.ReadRune()
+}
+
+func TestRuleLexReadsOnlyWhatTheRuleNeeds(t *tst.T) {
+	c := Use(t)
+	source := &testCountingRuneReader {
+		reader: strings.NewReader("->" + strings.Repeat("x", 1000)),
+	}
+	input := &LexerInput {
+		source: source,
+		location: StartOfFile("test"),
+	}
+	arrow := RuleLex(Sequence[testRune, int, *Packet[testRune], string](
+		The(0),
+		testCount[*Packet[testRune]],
+		testRuneToken('-'),
+		testRuneToken('>'),
+	))
+	AssertThat(c, arrow.MatchToken(input)).Is(EqualTo(2))
+	AssertThat(c, source.count).Is(LessThan(5))
+	AssertThat(c, input.Text()).Is(EqualTo("->x"))
+}
+
+func TestFormatPacketLexerToken(t *tst.T) {
+	c := Use(t)
+	tokens, err := lexTestInput(newTestLexer(), "x foo")
+	AssertThat(c, err).Is(ZeroValue[error]())
+	AssertThat(c, len(tokens)).Is(EqualTo(2))
+	packet := &Packet[Locatable[Token[testKind]]] {
+		Item: tokens[1],
+	}
+	AssertThat(c, FormatPacket(packet)).Is(EqualTo("\"foo\" at test:1:3"))
+	err = &SyntaxError[Locatable[Token[testKind]], string] {
+		Found: packet,
+		Expected: []string {"number"},
+	}
+	AssertThat(c, err.Error()).Is(EqualTo("Expected number near \"foo\" at test:1:3"))
+}
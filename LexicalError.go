@@ -0,0 +1,14 @@
+package gorecdesc
+
+import (
+	"fmt"
+)
+
+type LexicalError struct {
+	Found rune
+	Location Location
+}
+
+func(err *LexicalError) Error() string {
+	return fmt.Sprintf("Unexpected character %q at %s", err.Found, err.Location.Format())
+}
@@ -3,12 +3,16 @@ package gorecdesc
 import (
 	"fmt"
 	"strconv"
+	"strings"
 )
 
 type Location struct {
 	File string
 	Line uint
 	Column uint
+	ByteOffset uint64
+	RuneOffset uint64
+	IncludedFrom *Location
 }
 
 func(location *Location) isEmpty() bool {
@@ -16,7 +20,32 @@ func(location *Location) isEmpty() bool {
 }
 
 func(location *Location) Format() string {
-	if location == nil || location.isEmpty() {
+	if location == nil {
+		return "<unknown location>"
+	}
+	formatted := location.formatHere()
+	if location.IncludedFrom == nil {
+		return formatted
+	}
+	var builder strings.Builder
+	builder.WriteString(formatted)
+	for parent := location.IncludedFrom; parent != nil; parent = parent.IncludedFrom {
+		builder.WriteString(", included from ")
+		builder.WriteString(parent.formatHere())
+	}
+	return builder.String()
+}
+
+func(location *Location) IncludeDepth() int {
+	var depth int
+	for parent := location.IncludedFrom; parent != nil; parent = parent.IncludedFrom {
+		depth++
+	}
+	return depth
+}
+
+func(location *Location) formatHere() string {
+	if location.isEmpty() {
 		return "<unknown location>"
 	}
 	var f, l, c string
@@ -53,6 +82,28 @@ func(location *Location) NextColumn() {
 	}
 }
 
+func(location *Location) NextRune(r rune, size int) {
+	if r == '\n' {
+		location.NextLine()
+	} else {
+		location.NextColumn()
+	}
+	location.ByteOffset += uint64(size)
+	location.RuneOffset++
+}
+
+func(location *Location) NextByte(b byte) {
+	if b == byte('\n') {
+		location.NextLine()
+	} else {
+		location.NextColumn()
+	}
+	location.ByteOffset++
+	if b & 0xC0 != 0x80 {
+		location.RuneOffset++
+	}
+}
+
 func StartOfFile(file string) Location {
 	return Location {
 		File: file,
@@ -66,3 +117,9 @@ func SomewhereInFile(file string) Location {
 		File: file,
 	}
 }
+
+func IncludedFile(file string, from Location) Location {
+	location := StartOfFile(file)
+	location.IncludedFrom = &from
+	return location
+}
@@ -0,0 +1,62 @@
+package gorecdesc
+
+import (
+	tst "testing"
+	. "github.com/UncleSniper/gotest"
+)
+
+func TestLocationNextRune(t *tst.T) {
+	c := Use(t)
+	location := StartOfFile("foo.txt")
+	location.NextRune('ä', 2)
+	location.NextRune('\n', 1)
+	location.NextRune('x', 1)
+	AssertThat(c, location.Line).Is(EqualTo[uint](2))
+	AssertThat(c, location.Column).Is(EqualTo[uint](2))
+	AssertThat(c, location.ByteOffset).Is(EqualTo[uint64](4))
+	AssertThat(c, location.RuneOffset).Is(EqualTo[uint64](3))
+}
+
+func TestLocationNextByte(t *tst.T) {
+	c := Use(t)
+	location := StartOfFile("foo.txt")
+	for _, b := range []byte("ä\nx") {
+		location.NextByte(b)
+	}
+	AssertThat(c, location.Line).Is(EqualTo[uint](2))
+	AssertThat(c, location.ByteOffset).Is(EqualTo[uint64](4))
+	AssertThat(c, location.RuneOffset).Is(EqualTo[uint64](3))
+}
+
+func TestSpanText(t *tst.T) {
+	c := Use(t)
+	source := "let ä = 42"
+	start := StartOfFile("foo.txt")
+	for _, r := range "let " {
+		start.NextRune(r, 1)
+	}
+	end := start
+	end.NextRune('ä', 2)
+	span := Span {
+		Start: start,
+		End: end,
+	}
+	AssertThat(c, span.Text(source)).Is(EqualTo("ä"))
+	AssertThat(c, span.ByteLength()).Is(EqualTo[uint64](2))
+	AssertThat(c, span.RuneLength()).Is(EqualTo[uint64](1))
+	AssertThat(c, span.Format()).Is(EqualTo("foo.txt:1:5-6"))
+}
+
+func TestLocationFormatIncludeTrail(t *tst.T) {
+	c := Use(t)
+	outer := StartOfFile("main.dsl")
+	outer.Line = 3
+	middle := IncludedFile("a.dsl", outer)
+	middle.Line = 12
+	middle.Column = 9
+	inner := IncludedFile("b.dsl", middle)
+	inner.NextColumn()
+	AssertThat(c, inner.Format()).Is(EqualTo("b.dsl:1:2, included from a.dsl:12:9, included from main.dsl:3:1"))
+	AssertThat(c, inner.IncludeDepth()).Is(EqualTo(2))
+	AssertThat(c, outer.Format()).Is(EqualTo("main.dsl:3:1"))
+}
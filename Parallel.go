@@ -1,7 +1,14 @@
 package gorecdesc
 
+import (
+	"sync"
+	"sync/atomic"
+)
+
 type Parallel[ReadT any, OutT any, ExpectT any] struct {
 	states []*parallelState[ReadT, OutT, ExpectT]
+	finished chan struct{}
+	pruned bool
 }
 
 type parallelState[ReadT any, OutT any, ExpectT any] struct {
@@ -10,7 +17,21 @@ type parallelState[ReadT any, OutT any, ExpectT any] struct {
 	innerAckChannel chan Acknowledgement
 	innerResultChannel chan *Result[ReadT, OutT, ExpectT]
 	result *Result[ReadT, OutT, ExpectT]
+	detached bool
+	holding bool
+	awaiting bool
 	skipped []*Packet[ReadT]
+	cut *atomic.Bool
+	outerCut *atomic.Bool
+	abandon chan struct{}
+	abandonOnce sync.Once
+	outerAbandoned <-chan struct{}
+	done <-chan struct{}
+}
+
+type detachedResult[ReadT any, OutT any, ExpectT any] struct {
+	stateIndex int
+	result *Result[ReadT, OutT, ExpectT]
 }
 
 func(par *Parallel[ReadT, OutT, ExpectT]) Add(
@@ -22,6 +43,11 @@ func(par *Parallel[ReadT, OutT, ExpectT]) Add(
 		outerAckChannel: reader.ackChannel,
 		innerAckChannel: make(chan Acknowledgement),
 		innerResultChannel: make(chan *Result[ReadT, OutT, ExpectT]),
+		cut: new(atomic.Bool),
+		outerCut: reader.cutMark,
+		abandon: make(chan struct{}),
+		outerAbandoned: reader.abandoned,
+		done: reader.done,
 	}
 	if debugOn {
 		debugf(
@@ -32,9 +58,225 @@ func(par *Parallel[ReadT, OutT, ExpectT]) Add(
 	}
 	par.states = append(par.states, state)
 	reader.ackChannel = state.innerAckChannel
+	reader.cutMark = state.cut
+	reader.abandoned = state.abandon
+	if state.outerAbandoned != nil {
+		// we might be running in a branch that an enclosing Parallel abandons
+		if par.finished == nil {
+			par.finished = make(chan struct{})
+		}
+		go func(finished <-chan struct{}) {
+			select {
+				case <-state.outerAbandoned:
+					state.abandonBranch()
+				case <-finished:
+			}
+		}(par.finished)
+	}
 	go rule(reader, state.innerResultChannel)
 }
 
+func(state *parallelState[ReadT, OutT, ExpectT]) abandonBranch() {
+	state.abandonOnce.Do(func() {
+		close(state.abandon)
+	})
+}
+
+func(state *parallelState[ReadT, OutT, ExpectT]) relayOutward(ack Acknowledgement) {
+	// the child may be rewiring its reader concurrently, so stick to what we captured in Add
+	select {
+		case state.outerAckChannel <- ack:
+			return
+		default:
+	}
+	select {
+		case state.outerAckChannel <- ack:
+		case <-state.done:
+		case <-state.outerAbandoned:
+	}
+}
+
+func(par *Parallel[ReadT, OutT, ExpectT]) IsCut(stateIndex int) bool {
+	return par.states[stateIndex].cut.Load()
+}
+
+func(par *Parallel[ReadT, OutT, ExpectT]) CutIndex() int {
+	for stateIndex, state := range par.states {
+		if state.cut.Load() {
+			return stateIndex
+		}
+	}
+	return -1
+}
+
+func(par *Parallel[ReadT, OutT, ExpectT]) detach(
+	stateIndex int,
+	detachedResults chan<- detachedResult[ReadT, OutT, ExpectT],
+) {
+	state := par.states[stateIndex]
+	state.detached = true
+	go func(resultChannel <-chan *Result[ReadT, OutT, ExpectT]) {
+		detachedResults <- detachedResult[ReadT, OutT, ExpectT] {
+			stateIndex: stateIndex,
+			result: <-resultChannel,
+		}
+	}(state.innerResultChannel)
+}
+
+func(par *Parallel[ReadT, OutT, ExpectT]) prune(detachedResults chan<- detachedResult[ReadT, OutT, ExpectT]) {
+	cutIndex := par.CutIndex()
+	if par.pruned || cutIndex < 0 {
+		return
+	}
+	par.pruned = true
+	for stateIndex, state := range par.states {
+		if state.cut.Load() || state.result != nil {
+			continue
+		}
+		if debugOn {
+			debugf(
+				"[Parallel.Await] State %d cut, abandoning state %d with reader %s\n",
+				cutIndex,
+				stateIndex,
+				debugReader(state.reader),
+			)
+		}
+		if !state.detached {
+			// the child just acknowledged the batch, so this covers the next one
+			state.relayOutward(ACK_UNSUBSCRIBE_ON_ERROR)
+			par.detach(stateIndex, detachedResults)
+		}
+		state.abandonBranch()
+	}
+}
+
+func(state *parallelState[ReadT, OutT, ExpectT]) resultAckChannel() AckChannel {
+	if state.result.Reader == state.reader {
+		return state.outerAckChannel
+	}
+	return state.result.Reader.ackChannel
+}
+
+func(par *Parallel[ReadT, OutT, ExpectT]) finish(
+	stateIndex int,
+	result *Result[ReadT, OutT, ExpectT],
+	running bool,
+) {
+	state := par.states[stateIndex]
+	state.result = result
+	if running && (result.Error != nil || result.Reader != state.reader) && state.reader.owesAck() {
+		// child abandoned our reader without unsubscribing it
+		if debugOn {
+			debugf(
+				"[Parallel.Await] State %d abandoned reader %s, issuing ACK_UNSUBSCRIBE_ON_ERROR on its behalf\n",
+				stateIndex,
+				debugReader(state.reader),
+			)
+		}
+		state.reader.unsubscribed = true
+		state.relayOutward(ACK_UNSUBSCRIBE_ON_ERROR)
+	}
+	state.holding = result.Error == nil && result.Reader.owesAck()
+	if debugOn {
+		debugf(
+			"[Parallel.Await] State %d with reader %s finished (running = %v, holding = %v) with result = %s\n",
+			stateIndex,
+			debugReader(state.reader),
+			running,
+			state.holding,
+			debugResult(result),
+		)
+	}
+}
+
+func(par *Parallel[ReadT, OutT, ExpectT]) skip(stateIndex int) {
+	state := par.states[stateIndex]
+	reader := state.result.Reader
+	state.skipped = append(state.skipped, reader.skipBatch()...)
+	state.holding = false
+	state.awaiting = true
+	if debugOn {
+		debugf(
+			"[Parallel.Await] Skipping state %d with reader %s ahead: Registered skipped packets = %s, " +
+					"issuing ACK_KEEP_SUBSCRIPTION outward\n",
+			stateIndex,
+			debugReader(reader),
+			debugPacketList(state.skipped),
+		)
+	}
+	reader.relayAck(state.resultAckChannel(), ACK_KEEP_SUBSCRIPTION)
+}
+
+func(par *Parallel[ReadT, OutT, ExpectT]) skipAhead(
+	alive bool,
+	detachedResults <-chan detachedResult[ReadT, OutT, ExpectT],
+) {
+	// acknowledge the batches held by finished children, so the others can keep going
+	var awaiting []*parallelState[ReadT, OutT, ExpectT]
+	var ackedOffset uint64
+	for stateIndex, state := range par.states {
+		if !state.holding {
+			continue
+		}
+		batch := state.result.Reader.batch
+		if batch[len(batch) - 1].EOF {
+			// nothing follows, so nobody needs us to let go of this batch
+			continue
+		}
+		ackedOffset = batch[0].Offset
+		par.skip(stateIndex)
+		awaiting = append(awaiting, state)
+	}
+	if len(awaiting) == 0 {
+		if !alive {
+			// only detached children are left; wait for one of them
+			detached := <-detachedResults
+			par.finish(detached.stateIndex, detached.result, false)
+		}
+		return
+	}
+	// await the next batch, but detached children might finish in the meantime
+	for {
+		select {
+			case batch := <-awaiting[0].result.Reader.packetChannel:
+				for index, state := range awaiting {
+					reader := state.result.Reader
+					if index == 0 {
+						reader.acceptBatch(batch)
+					} else {
+						reader.acceptBatch(reader.receiveBatch())
+					}
+					state.awaiting = false
+					state.holding = true
+				}
+				return
+			case <-awaiting[0].result.Reader.done:
+				par.endAwaiting(awaiting)
+				return
+			case <-awaiting[0].result.Reader.abandoned:
+				par.endAwaiting(awaiting)
+				return
+			case detached := <-detachedResults:
+				par.finish(detached.stateIndex, detached.result, false)
+				state := par.states[detached.stateIndex]
+				if state.holding && state.result.Reader.batch[0].Offset == ackedOffset {
+					par.skip(detached.stateIndex)
+					awaiting = append(awaiting, state)
+				}
+		}
+	}
+}
+
+func(par *Parallel[ReadT, OutT, ExpectT]) endAwaiting(awaiting []*parallelState[ReadT, OutT, ExpectT]) {
+	// no further batch may be coming, in which case the Readers make up an EOF packet
+	for _, state := range awaiting {
+		reader := state.result.Reader
+		reader.acceptBatch(reader.receiveBatch())
+		state.awaiting = false
+		state.holding = true
+	}
+}
+
 func(par *Parallel[ReadT, OutT, ExpectT]) Await() []*Result[ReadT, OutT, ExpectT] {
 	stateCount := len(par.states)
 	if stateCount == 0 {
@@ -43,154 +285,105 @@ func(par *Parallel[ReadT, OutT, ExpectT]) Await() []*Result[ReadT, OutT, ExpectT
 		}
 		return nil
 	}
-	acks := make([]Acknowledgement, stateCount)
+	detachedResults := make(chan detachedResult[ReadT, OutT, ExpectT], stateCount)
 	var roundCount uint64
 	for {
 		alive := false
 		// collect all acknowledgements
 		for stateIndex, state := range par.states {
-			if state.result != nil {
-				if debugOn {
-					debugf(
-						"[Parallel.Await] Collecting acks for round %d: Skipping state %d with reader %s; " +
-								"it already has result = %s\n",
-						roundCount,
-						stateIndex,
-						debugReader(state.reader),
-						debugResult(state.result),
-					)
-				}
+			if state.result != nil || state.detached {
 				continue
 			}
-			ack := <-state.innerAckChannel
-			if debugOn {
-				debugf(
-					"[Parallel.Await] Collecting acks for round %d: State %d with reader %s sent %s\n",
-					roundCount,
-					stateIndex,
-					debugReader(state.reader),
-					debugAck(ack),
-				)
-			}
-			acks[stateIndex] = ack
-			if ack == ACK_KEEP_SUBSCRIPTION {
-				alive = true
-			}
-		}
-		if debugOn {
-			debugf(
-				"[Parallel.Await] Round %d (for reader #0 = %s) has alive = %v\n",
-				roundCount,
-				debugReader(par.states[0].reader),
-				alive,
-			)
-		}
-		// prepare for next round
-		for stateIndex, state := range par.states {
-			if state.result == nil {
-				if debugOn {
-					debugf(
-						"[Parallel.Await] Preparing for next round in round %d: " +
-								"State %d with reader %s has no result\n",
-						roundCount,
-						stateIndex,
-						debugReader(state.reader),
-					)
-				}
-				if acks[stateIndex] == ACK_KEEP_SUBSCRIPTION {
-					// child wants to keep going, so we simply propagate the acknowledgement
+			select {
+				case ack := <-state.innerAckChannel:
 					if debugOn {
 						debugf(
-							"[Parallel.Await] Preparing for next round in round %d: " +
-									"State %d with reader %s continuing, propagating ACK_KEEP_SUBSCRIPTION\n",
+							"[Parallel.Await] Collecting acks for round %d: State %d with reader %s sent %s, " +
+									"propagating it\n",
 							roundCount,
 							stateIndex,
 							debugReader(state.reader),
+							debugAck(ack),
 						)
 					}
-					state.outerAckChannel <- ACK_KEEP_SUBSCRIPTION
-					continue
-				} else {
-					// child is unsubscribing
-					state.result = <-state.innerResultChannel
-					if debugOn {
-						debugf(
-							"[Parallel.Await] Preparing for next round in round %d: " +
-									"State %d with reader %s unsubscribing with %s, fetched result = %s\n",
-							roundCount,
-							stateIndex,
-							debugReader(state.reader),
-							debugAck(acks[stateIndex]),
-							debugResult(state.result),
-						)
+					state.relayOutward(ack)
+					if ack == ACK_KEEP_SUBSCRIPTION {
+						alive = true
+					} else {
+						// child is unsubscribing, but it may keep going on a split reader
+						par.detach(stateIndex, detachedResults)
 					}
-				}
-			}
-			if state.result.Error == nil {
-				// child is done, but others must be able to keep going
-				reader := state.result.Reader
-				current := reader.Current()
-				state.skipped = append(state.skipped, current)
-				state.outerAckChannel <- ACK_KEEP_SUBSCRIPTION
-				next := reader.NextFromChannel()
-				if debugOn {
-					debugf(
-						"[Parallel.Await] Preparing for next round in round %d: " +
-								"State %d with reader %s has no error, thus skipping it ahead: " +
-								"Registering skipped packet = %s, issuing ACK_KEEP_SUBSCRIPTION outward " +
-								"-> next packet = %s\n",
-						roundCount,
-						stateIndex,
-						debugReader(state.reader),
-						debugPacket(current),
-						debugPacket(next),
-					)
-				}
-			} else {
-				// child is in error, therefore its reader is already unsubscribed
-				if debugOn {
-					debugf(
-						"[Parallel.Await] Preparing for next round in round %d: " +
-								"State %d with reader %s has error %+v, doing nothing\n",
-						roundCount,
-						stateIndex,
-						debugReader(state.reader),
-						state.result.Error,
-					)
-				}
+				case result := <-state.innerResultChannel:
+					// child is done without having acknowledged its batch
+					par.finish(stateIndex, result, true)
 			}
 		}
-		// bail out
-		if !alive {
-			if debugOn {
-				debugf(
-					"[Parallel.Await] Round %d (for reader #0 = %s): Bailing out (nothing alive)\n",
-					roundCount,
-					debugReader(par.states[0].reader),
-				)
+		par.prune(detachedResults)
+		alive = false
+		for _, state := range par.states {
+			if state.result == nil && !state.detached {
+				alive = true
 			}
-			results := make([]*Result[ReadT, OutT, ExpectT], stateCount)
-			for stateIndex, state := range par.states {
-				results[stateIndex] = state.result
-				// restore reader
-				reader := state.result.Reader
-				reader.ackChannel = state.outerAckChannel
-				reader.Reprovide(state.skipped, true)
+		}
+		// collect results of detached children
+		pending := alive
+		for draining := true; draining; {
+			select {
+				case detached := <-detachedResults:
+					par.finish(detached.stateIndex, detached.result, false)
+				default:
+					draining = false
 			}
-			if debugOn {
-				debugf(
-					"[Parallel.Await] Round %d (for reader #0 = %s): Completing bailout with results = %s\n",
-					roundCount,
-					debugReader(par.states[0].reader),
-					debugResultList(results),
-				)
+		}
+		for _, state := range par.states {
+			if state.result == nil {
+				pending = true
 			}
-			return results
 		}
+		if debugOn {
+			debugf(
+				"[Parallel.Await] Round %d (for reader #0 = %s) has alive = %v, pending = %v\n",
+				roundCount,
+				debugReader(par.states[0].reader),
+				alive,
+				pending,
+			)
+		}
+		// bail out
+		if !pending {
+			break
+		}
+		// prepare for next round
+		par.skipAhead(alive, detachedResults)
 		roundCount++
 	}
+	results := make([]*Result[ReadT, OutT, ExpectT], stateCount)
+	for stateIndex, state := range par.states {
+		results[stateIndex] = state.result
+		// restore reader
+		state.reader.ackChannel = state.outerAckChannel
+		state.reader.cutMark = state.outerCut
+		state.reader.abandoned = state.outerAbandoned
+		state.result.Reader.cutMark = state.outerCut
+		state.result.Reader.abandoned = state.outerAbandoned
+		state.result.Reader.Reprovide(state.skipped, true)
+	}
+	if par.finished != nil {
+		close(par.finished)
+	}
+	if debugOn {
+		debugf(
+			"[Parallel.Await] Round %d (for reader #0 = %s): Completing bailout with results = %s\n",
+			roundCount,
+			debugReader(par.states[0].reader),
+			debugResultList(results),
+		)
+	}
+	return results
 }
 
 func(par *Parallel[ReadT, OutT, ExpectT]) Reset() {
 	par.states = nil
+	par.finished = nil
+	par.pruned = false
 }
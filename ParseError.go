@@ -17,6 +17,8 @@ type ParseError[ReadT any, ExpectT any] interface {
 	OfferStructure(Commission, string)
 	CommisionAndStructure() (Commission, string)
 	SubErrors() []ParseError[ReadT, ExpectT]
+	OfferRelated(RelatedPacket[ReadT])
+	RelatedPackets() []RelatedPacket[ReadT]
 }
 
 func MergeExpectations[ExpectT any](
@@ -4,7 +4,7 @@ import (
 	"sync/atomic"
 )
 
-type PacketChannel[ReadT any] <-chan *Packet[ReadT]
+type PacketChannel[ReadT any] <-chan []*Packet[ReadT]
 
 type Acknowledgement uint
 
@@ -21,9 +21,20 @@ type Reader[ReadT any] struct {
 	dispatcher *Dispatcher[ReadT]
 	packetChannel PacketChannel[ReadT]
 	ackChannel AckChannel
+	done <-chan struct{}
+	abandoned <-chan struct{}
+	cutMark *atomic.Bool
 	current *Packet[ReadT]
+	previous *Packet[ReadT]
+	trail []*Packet[ReadT]
+	trailUsers int
+	batch []*Packet[ReadT]
+	inBatch int
+	batchAcked bool
+	unsubscribed bool
 	prepended [][]*Packet[ReadT]
 	inPrepended int
+	state any
 }
 
 var readerID atomic.Uint64
@@ -32,17 +43,66 @@ func NewReaderID() uint64 {
 	return readerID.Add(1)
 }
 
+func(reader *Reader[ReadT]) ID() uint64 {
+	return reader.id
+}
+
+func(reader *Reader[ReadT]) State() any {
+	return reader.state
+}
+
+func(reader *Reader[ReadT]) SetState(state any) {
+	reader.state = state
+}
+
 func(reader *Reader[ReadT]) Current() *Packet[ReadT] {
 	return reader.current
 }
 
-func(reader *Reader[ReadT]) Next() *Packet[ReadT] {
-	if len(reader.prepended) == 0 {
-		if debugOn {
-			debugf("[Reader %s] Retrieving next packet from channel\n", debugReader(reader))
+func(reader *Reader[ReadT]) Previous() *Packet[ReadT] {
+	return reader.previous
+}
+
+func(reader *Reader[ReadT]) leaveCurrent() {
+	reader.previous = reader.current
+	if reader.trailUsers > 0 {
+		reader.trail = append(reader.trail, reader.current)
+	}
+}
+
+// while someone holds the trail, consumed packets are remembered, so that
+// Reprovide can tell which packet comes before the ones being handed back
+func(reader *Reader[ReadT]) holdTrail() {
+	reader.trailUsers++
+}
+
+func(reader *Reader[ReadT]) releaseTrail() {
+	if reader.trailUsers > 0 {
+		reader.trailUsers--
+	}
+	if reader.trailUsers == 0 {
+		reader.trail = nil
+	}
+}
+
+func(reader *Reader[ReadT]) previousBefore(packet *Packet[ReadT]) *Packet[ReadT] {
+	if reader.previous == nil || reader.previous.Offset < packet.Offset {
+		return reader.previous
+	}
+	for index := len(reader.trail) - 1; index >= 0; index-- {
+		if reader.trail[index].Offset < packet.Offset {
+			// clip the capacity, since splits may share the rest of the array
+			reader.trail = reader.trail[:index + 1:index + 1]
+			return reader.trail[index]
 		}
-		reader.current = <-reader.packetChannel
-	} else {
+	}
+	reader.trail = nil
+	return nil
+}
+
+func(reader *Reader[ReadT]) Next() *Packet[ReadT] {
+	reader.leaveCurrent()
+	if len(reader.prepended) > 0 {
 		if debugOn {
 			debugf("[Reader %s] Unqueuing next packet from prepended list\n", debugReader(reader))
 		}
@@ -53,6 +113,17 @@ func(reader *Reader[ReadT]) Next() *Packet[ReadT] {
 			reader.prepended = reader.prepended[1:]
 			reader.inPrepended = 0
 		}
+	} else if reader.inBatch < len(reader.batch) {
+		if debugOn {
+			debugf("[Reader %s] Retrieving next packet from current batch\n", debugReader(reader))
+		}
+		reader.current = reader.batch[reader.inBatch]
+		reader.inBatch++
+	} else {
+		if debugOn {
+			debugf("[Reader %s] Retrieving next batch from channel\n", debugReader(reader))
+		}
+		reader.acceptBatch(reader.receiveBatch())
 	}
 	if debugOn {
 		debugf("[Reader %s] Set current packet\n", debugReader(reader))
@@ -62,18 +133,110 @@ func(reader *Reader[ReadT]) Next() *Packet[ReadT] {
 
 func(reader *Reader[ReadT]) NextFromChannel() *Packet[ReadT] {
 	if debugOn {
-		debugf("[Reader %s] Explicitly retrieving next packet from channel\n", debugReader(reader))
+		debugf("[Reader %s] Explicitly retrieving next batch from channel\n", debugReader(reader))
 	}
-	reader.current = <-reader.packetChannel
+	reader.leaveCurrent()
+	reader.acceptBatch(reader.receiveBatch())
 	if debugOn {
 		debugf("[Reader %s] Set current packet\n", debugReader(reader))
 	}
 	return reader.current
 }
 
+func(reader *Reader[ReadT]) receiveBatch() []*Packet[ReadT] {
+	select {
+		case batch := <-reader.packetChannel:
+			return batch
+		case <-reader.done:
+			return reader.closedBatch()
+		case <-reader.abandoned:
+			return reader.abandonedBatch()
+	}
+}
+
+func(reader *Reader[ReadT]) closedBatch() []*Packet[ReadT] {
+	// the Dispatcher was closed, so pretend the input ends here
+	if debugOn {
+		debugf("[Reader %s] Dispatcher was closed, synthesizing EOF packet\n", debugReader(reader))
+	}
+	reader.unsubscribed = true
+	return reader.syntheticEOF()
+}
+
+func(reader *Reader[ReadT]) abandonedBatch() []*Packet[ReadT] {
+	// a sibling branch cut, so nobody is interested in what we read from here on
+	if debugOn {
+		debugf("[Reader %s] Branch was abandoned, synthesizing EOF packet\n", debugReader(reader))
+	}
+	return reader.syntheticEOF()
+}
+
+func(reader *Reader[ReadT]) syntheticEOF() []*Packet[ReadT] {
+	var offset uint64
+	if reader.current != nil {
+		offset = reader.current.Offset
+		if !reader.current.EOF {
+			offset++
+		}
+	}
+	return []*Packet[ReadT] {
+		&Packet[ReadT] {
+			Offset: offset,
+			EOF: true,
+		},
+	}
+}
+
+func(reader *Reader[ReadT]) acceptBatch(batch []*Packet[ReadT]) {
+	reader.batch = batch
+	reader.inBatch = 1
+	reader.batchAcked = false
+	reader.current = batch[0]
+}
+
+func(reader *Reader[ReadT]) owesAck() bool {
+	return reader.batch != nil && !reader.batchAcked && !reader.unsubscribed
+}
+
+func(reader *Reader[ReadT]) atEndOfBatch() bool {
+	return len(reader.prepended) == 0 &&
+			len(reader.batch) > 0 &&
+			reader.inBatch >= len(reader.batch) &&
+			reader.current == reader.batch[len(reader.batch) - 1]
+}
+
+func(reader *Reader[ReadT]) skipBatch() []*Packet[ReadT] {
+	skipped := []*Packet[ReadT] {reader.current}
+	if len(reader.prepended) > 0 {
+		skipped = append(skipped, reader.prepended[0][reader.inPrepended:]...)
+		for _, prepended := range reader.prepended[1:] {
+			skipped = append(skipped, prepended...)
+		}
+	}
+	skipped = append(skipped, reader.batch[reader.inBatch:]...)
+	reader.prepended = nil
+	reader.inPrepended = 0
+	reader.inBatch = len(reader.batch)
+	reader.batchAcked = true
+	return skipped
+}
+
 func(reader *Reader[ReadT]) Split() *Reader[ReadT] {
-	clone := reader.dispatcher.Subscribe()
+	clone := reader.dispatcher.subscribe(reader.owesAck())
 	clone.current = reader.current
+	clone.previous = reader.previous
+	clone.trail = reader.trail[:len(reader.trail):len(reader.trail)]
+	clone.trailUsers = reader.trailUsers
+	clone.batch = reader.batch
+	clone.inBatch = reader.inBatch
+	clone.batchAcked = reader.batchAcked
+	if len(reader.prepended) > 0 {
+		clone.prepended = append([][]*Packet[ReadT](nil), reader.prepended...)
+		clone.inPrepended = reader.inPrepended
+	}
+	clone.state = cloneState(reader.state)
+	clone.abandoned = reader.abandoned
+	clone.cutMark = reader.cutMark
 	if debugOn {
 		debugf("[Reader %s] Splitting off new Reader %s\n", debugReader(reader), debugReader(clone))
 	}
@@ -81,28 +244,75 @@ func(reader *Reader[ReadT]) Split() *Reader[ReadT] {
 }
 
 func(reader *Reader[ReadT]) Acknowledge(unsubscribe Acknowledgement) {
-	if len(reader.prepended) == 0 {
+	if unsubscribe == ACK_KEEP_SUBSCRIPTION && !reader.atEndOfBatch() {
 		if debugOn {
-			debugf("[Reader %s] Sending ack %s\n", debugReader(reader), debugAck(unsubscribe))
+			debugf(
+				"[Reader %s] Dropping ack %s since the current batch is not exhausted yet\n",
+				debugReader(reader),
+				debugAck(unsubscribe),
+			)
 		}
-		reader.ackChannel <- unsubscribe
-		if debugOn {
-			debugf("[Reader %s] Sent ack %s\n", debugReader(reader), debugAck(unsubscribe))
-		}
-	} else if debugOn {
-		debugf(
-			"[Reader %s] Dropping ack %s since prepended list is non-empty\n",
-			debugReader(reader),
-			debugAck(unsubscribe),
-		)
+		return
 	}
+	reader.sendAck(unsubscribe)
 }
 
 func(reader *Reader[ReadT]) AcknowledgeOnChannel(unsubscribe Acknowledgement) {
 	if debugOn {
 		debugf("[Reader %s] Explicitly sending %s on channel\n", debugReader(reader), debugAck(unsubscribe))
 	}
-	reader.ackChannel <- unsubscribe
+	reader.sendAck(unsubscribe)
+}
+
+func(reader *Reader[ReadT]) sendAck(unsubscribe Acknowledgement) {
+	if !reader.owesAck() {
+		if debugOn {
+			debugf(
+				"[Reader %s] Dropping ack %s since the current batch needs no acknowledgement\n",
+				debugReader(reader),
+				debugAck(unsubscribe),
+			)
+		}
+		return
+	}
+	if debugOn {
+		debugf("[Reader %s] Sending ack %s\n", debugReader(reader), debugAck(unsubscribe))
+	}
+	if unsubscribe == ACK_KEEP_SUBSCRIPTION {
+		reader.batchAcked = true
+	} else {
+		reader.unsubscribed = true
+	}
+	if !reader.relayAck(reader.ackChannel, unsubscribe) {
+		if debugOn {
+			debugf(
+				"[Reader %s] Dropping ack %s since nobody is listening anymore\n",
+				debugReader(reader),
+				debugAck(unsubscribe),
+			)
+		}
+		return
+	}
+	if debugOn {
+		debugf("[Reader %s] Sent ack %s\n", debugReader(reader), debugAck(unsubscribe))
+	}
+}
+
+func(reader *Reader[ReadT]) relayAck(channel AckChannel, ack Acknowledgement) bool {
+	// prefer delivering the ack, even if the branch has been abandoned meanwhile
+	select {
+		case channel <- ack:
+			return true
+		default:
+	}
+	select {
+		case channel <- ack:
+			return true
+		case <-reader.done:
+			return false
+		case <-reader.abandoned:
+			return false
+	}
 }
 
 func(reader *Reader[ReadT]) Reprovide(packets []*Packet[ReadT], andCurrent bool) {
@@ -117,35 +327,24 @@ func(reader *Reader[ReadT]) Reprovide(packets []*Packet[ReadT], andCurrent bool)
 			andCurrent,
 		)
 	}
-	if len(reader.prepended) == 0 {
-		if andCurrent {
-			packets = append(packets, reader.current)
-		}
-		reader.current = packets[0]
-		packets = packets[1:]
-		if len(packets) == 0 {
-			reader.prepended = nil
-		} else {
-			reader.prepended = [][]*Packet[ReadT] {packets}
-		}
-	} else {
-		if reader.inPrepended > 0 {
-			last := len(reader.prepended) - 1
-			inPrepended := reader.inPrepended
-			if andCurrent {
-				inPrepended--
-			}
-			if inPrepended > 0 {
-				reader.prepended[last] = reader.prepended[last][inPrepended:]
-			}
-		} else if andCurrent {
-			packets = append(packets, reader.current)
-		}
-		reader.current = packets[0]
-		if len(packets) > 1 {
-			reader.prepended = append(reader.prepended, packets[1:])
+	replay := make([]*Packet[ReadT], 0, len(packets) + 1)
+	replay = append(replay, packets...)
+	if andCurrent {
+		replay = append(replay, reader.current)
+	}
+	var prepended [][]*Packet[ReadT]
+	if len(replay) > 1 {
+		prepended = append(prepended, replay[1:])
+	}
+	if len(reader.prepended) > 0 {
+		if front := reader.prepended[0][reader.inPrepended:]; len(front) > 0 {
+			prepended = append(prepended, front)
 		}
+		prepended = append(prepended, reader.prepended[1:]...)
 	}
+	reader.previous = reader.previousBefore(replay[0])
+	reader.current = replay[0]
+	reader.prepended = prepended
 	reader.inPrepended = 0
 	if debugOn {
 		debugf("[Reader %s] State after Reprovide\n", debugReader(reader))
@@ -185,7 +384,7 @@ func Intercept[ReadT any, OutT any, ExpectT any](
 					debugf("[Intercept for Reader %d] Received %s on inner channel\n", reader.id, debugResult(result))
 					debugf("[Intercept for Reader %d] Sending %s to outer channel\n", reader.id, debugAck(ack))
 				}
-				oldAckChannel <- ack
+				reader.relayAck(oldAckChannel, ack)
 				if interceptor != nil {
 					result = interceptor(ack, result)
 					if debugOn {
@@ -208,7 +407,7 @@ func Intercept[ReadT any, OutT any, ExpectT any](
 			if debugOn {
 				debugf("[Intercept for Reader %d] Sending ACK_KEEP_SUBSCRIPTION to outer channel\n", reader.id)
 			}
-			oldAckChannel <- ACK_KEEP_SUBSCRIPTION
+			reader.relayAck(oldAckChannel, ACK_KEEP_SUBSCRIPTION)
 			if interceptor != nil {
 				interceptor(ACK_KEEP_SUBSCRIPTION, nil)
 			}
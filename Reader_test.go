@@ -0,0 +1,35 @@
+package gorecdesc
+
+import (
+	"strings"
+	tst "testing"
+	. "github.com/UncleSniper/gotest"
+)
+
+func TestReaderReprovideBeforePending(t *tst.T) {
+	c := Use(t)
+	var disp Dispatcher[testRune]
+	disp.SetBatchSize(16)
+	reader := disp.Subscribe()
+	sent := make(chan error)
+	go func() {
+		sent <- SendRunes(&disp, strings.NewReader("abcde"), StartOfFile("test"))
+	}()
+	var consumed []*Packet[testRune]
+	for packet := reader.Next(); len(consumed) < 3; packet = reader.Next() {
+		consumed = append(consumed, packet)
+	}
+	AssertThat(c, reader.Current().Item.Symbol).Is(EqualTo('d'))
+	// put back "bc" in front of 'd', then 'a' in front of the pending packets
+	reader.Reprovide(consumed[1:], true)
+	AssertThat(c, reader.Current().Item.Symbol).Is(EqualTo('b'))
+	reader.Reprovide(consumed[:1], true)
+	var builder strings.Builder
+	for packet := reader.Current(); !packet.EOF; packet = reader.Next() {
+		builder.WriteRune(packet.Item.Symbol)
+	}
+	AssertThat(c, builder.String()).Is(EqualTo("abcde"))
+	reader.AcknowledgeOnChannel(ACK_UNSUBSCRIBE_ON_SUCCESS)
+	AssertThat(c, <-sent).Is(ZeroValue[error]())
+	disp.Close()
+}
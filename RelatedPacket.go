@@ -0,0 +1,20 @@
+package gorecdesc
+
+import (
+	"strings"
+)
+
+type RelatedPacket[ReadT any] struct {
+	Packet *Packet[ReadT]
+	Note string
+}
+
+func writeRelated[ReadT any](builder *strings.Builder, related []RelatedPacket[ReadT]) {
+	for _, relation := range related {
+		if len(relation.Note) == 0 {
+			continue
+		}
+		builder.WriteString("; ")
+		builder.WriteString(relation.Note)
+	}
+}
@@ -30,11 +30,6 @@ func MapRule[ReadT any, FromT any, ToT any, ExpectT any](
 			if debugOn {
 				debugf("[MapRule with Reader %s] Missing inner rule\n", debugReader(reader))
 			}
-			if formatUnmapped == nil {
-				formatUnmapped = func(ExpectT) string {
-					return "<missing mapped rule not formattable>"
-				}
-			}
 			current := reader.Current()
 			result = &Result[ReadT, ToT, ExpectT] {
 				Offset: current.Offset,
@@ -62,3 +57,88 @@ func MapRule[ReadT any, FromT any, ToT any, ExpectT any](
 		}
 	}
 }
+
+// wrappers fail rather than succeed on nothing when their inner rule is missing
+func missingRuleResult[ReadT any, OutT any, ExpectT any](
+	name string,
+	formatPacket func(*Packet[ReadT]) string,
+	reader *Reader[ReadT],
+) *Result[ReadT, OutT, ExpectT] {
+	if debugOn {
+		debugf("[%s with Reader %s] Missing inner rule, issuing ACK_UNSUBSCRIBE_ON_ERROR\n", name, debugReader(reader))
+	}
+	reader.Acknowledge(ACK_UNSUBSCRIBE_ON_ERROR)
+	current := reader.Current()
+	return &Result[ReadT, OutT, ExpectT] {
+		Offset: current.Offset,
+		Error: &SyntaxError[ReadT, ExpectT] {
+			Found: current,
+			FormatFound: formatPacket,
+		},
+		Reader: reader,
+	}
+}
+
+func TryMap[ReadT any, FromT any, ToT any, ExpectT any](
+	formatPacket func(*Packet[ReadT]) string,
+	innerRule Rule[ReadT, FromT, ExpectT],
+	mapping func(FromT) (ToT, error),
+) Rule[ReadT, ToT, ExpectT] {
+	return func(reader *Reader[ReadT], resultChannel ResultChannel[ReadT, ToT, ExpectT]) {
+		if debugOn {
+			debugf("Entering TryMap with Reader %s\n", debugReader(reader))
+		}
+		start := reader.Current()
+		var result *Result[ReadT, ToT, ExpectT]
+		if innerRule == nil {
+			result = missingRuleResult[ReadT, ToT, ExpectT]("TryMap", formatPacket, reader)
+		} else {
+			innerResult := RunRule(innerRule, reader)
+			if innerResult.Error != nil || mapping == nil {
+				var outValue ToT
+				result = SubstResult(innerResult, outValue)
+			} else {
+				outValue, err := mapping(innerResult.Result)
+				result = SubstResult(innerResult, outValue)
+				if err != nil {
+					// the input was well-formed, but its value is not acceptable
+					if debugOn {
+						debugf(
+							"[TryMap with Reader %s] Mapping rejected %+v: %s\n",
+							debugReader(reader),
+							innerResult.Result,
+							err.Error(),
+						)
+					}
+					innerResult.Reader.AcknowledgeOnChannel(ACK_UNSUBSCRIBE_ON_ERROR)
+					result.Error = &SyntaxError[ReadT, ExpectT] {
+						Found: start,
+						FormatFound: formatPacket,
+						Structure: innerResult.Structure,
+						Cause: err,
+					}
+				}
+			}
+		}
+		if debugOn {
+			debugf("[TryMap with Reader %s] Issuing %s\n", debugReader(reader), debugResult(result))
+		}
+		resultChannel <- result
+		if debugOn {
+			debugf("Leaving TryMap with Reader %s\n", debugReader(reader))
+		}
+	}
+}
+
+func Validate[ReadT any, OutT any, ExpectT any](
+	formatPacket func(*Packet[ReadT]) string,
+	innerRule Rule[ReadT, OutT, ExpectT],
+	validate func(OutT) error,
+) Rule[ReadT, OutT, ExpectT] {
+	return TryMap(formatPacket, innerRule, func(value OutT) (OutT, error) {
+		if validate == nil {
+			return value, nil
+		}
+		return value, validate(value)
+	})
+}
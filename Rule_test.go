@@ -0,0 +1,57 @@
+package gorecdesc
+
+import (
+	"fmt"
+	"strconv"
+	tst "testing"
+	. "github.com/UncleSniper/gotest"
+)
+
+func testByteRule() Rule[testRune, int, string] {
+	digits := MustRegex[string](nil, "digits", nil, `[0-9]+`)
+	return TryMap(nil, digits, func(digits RangeLocatable[string]) (int, error) {
+		value, err := strconv.Atoi(digits.Symbol)
+		if err == nil && value > 255 {
+			err = fmt.Errorf("Byte value %d out of range", value)
+		}
+		return value, err
+	})
+}
+
+func TestTryMap(t *tst.T) {
+	c := Use(t)
+	result, _ := parseTestInput(testByteRule(), "42;", 1)
+	AssertThat(c, result.Error == nil).Is(EqualTo(true))
+	AssertThat(c, result.Result).Is(EqualTo(42))
+	AssertThat(c, result.Offset).Is(EqualTo[uint64](2))
+	result, _ = parseTestInput(testByteRule(), "300;", 1)
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Byte value 300 out of range near '3' at test:1:1"))
+	AssertThat(c, result.Error.Start().Offset).Is(EqualTo[uint64](0))
+	AssertThat(c, result.Offset).Is(EqualTo[uint64](3))
+}
+
+func TestTryMapInChoice(t *tst.T) {
+	c := Use(t)
+	wide := MapRule[testRune, RangeLocatable[string], int, string](
+		nil,
+		"",
+		nil,
+		MustRegex[string](nil, "", nil, `[0-9]+`),
+		func(digits RangeLocatable[string]) int {
+			return -len(digits.Symbol)
+		},
+	)
+	rule := Choice[testRune, int, string]("", nil, "", nil, nil, nil, testByteRule(), wide)
+	result, _ := parseTestInput(rule, "1000", 2)
+	AssertThat(c, result.Error == nil).Is(EqualTo(true))
+	AssertThat(c, result.Result).Is(EqualTo(-4))
+}
+
+func TestValidate(t *tst.T) {
+	c := Use(t)
+	rule := Validate(nil, Literal[string](nil, "x", nil, "x"), func(RangeLocatable[string]) error {
+		return fmt.Errorf("No x allowed")
+	})
+	result, _ := parseTestInput(rule, "x", 1)
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("No x allowed near 'x' at test:1:1"))
+}
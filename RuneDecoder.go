@@ -0,0 +1,259 @@
+package gorecdesc
+
+import (
+	"io"
+	"bufio"
+	"errors"
+	"unicode/utf8"
+	"unicode/utf16"
+)
+
+type Encoding uint
+
+const (
+	ENC_DETECT Encoding = iota
+	ENC_UTF8
+	ENC_UTF16LE
+	ENC_UTF16BE
+	ENC_UTF32LE
+	ENC_UTF32BE
+	ENC_ASCII
+	ENC_LATIN1
+	ENC_WINDOWS1252
+)
+
+func(encoding Encoding) String() string {
+	switch encoding {
+		case ENC_DETECT:
+			return "auto-detected encoding"
+		case ENC_UTF8:
+			return "UTF-8"
+		case ENC_UTF16LE:
+			return "UTF-16LE"
+		case ENC_UTF16BE:
+			return "UTF-16BE"
+		case ENC_UTF32LE:
+			return "UTF-32LE"
+		case ENC_UTF32BE:
+			return "UTF-32BE"
+		case ENC_ASCII:
+			return "ASCII"
+		case ENC_LATIN1:
+			return "ISO-8859-1"
+		case ENC_WINDOWS1252:
+			return "Windows-1252"
+		default:
+			return "<unknown encoding>"
+	}
+}
+
+var windows1252High = [32]rune {
+	'€', -1, '‚', 'ƒ', '„', '…', '†', '‡',
+	'ˆ', '‰', 'Š', '‹', 'Œ', -1, 'Ž', -1,
+	-1, '‘', '’', '“', '”', '•', '–', '—',
+	'˜', '™', 'š', '›', 'œ', -1, 'ž', 'Ÿ',
+}
+
+var ErrUnknownEncoding = errors.New("Unknown encoding")
+
+type byteOrderMark struct {
+	encoding Encoding
+	bytes string
+}
+
+var byteOrderMarks = []byteOrderMark {
+	{ENC_UTF32LE, "\xFF\xFE\x00\x00"},
+	{ENC_UTF32BE, "\x00\x00\xFE\xFF"},
+	{ENC_UTF8, "\xEF\xBB\xBF"},
+	{ENC_UTF16LE, "\xFF\xFE"},
+	{ENC_UTF16BE, "\xFE\xFF"},
+}
+
+type RuneDecoder struct {
+	source *bufio.Reader
+	encoding Encoding
+	started bool
+	offset uint64
+}
+
+func NewRuneDecoder(source io.Reader, encoding Encoding) *RuneDecoder {
+	return &RuneDecoder {
+		source: bufio.NewReader(source),
+		encoding: encoding,
+	}
+}
+
+func(decoder *RuneDecoder) Encoding() Encoding {
+	return decoder.encoding
+}
+
+func(decoder *RuneDecoder) ByteOffset() uint64 {
+	return decoder.offset
+}
+
+func(decoder *RuneDecoder) ReadRune() (rune, int, error) {
+	if _, err := decoder.SkipByteOrderMark(); err != nil {
+		return 0, 0, err
+	}
+	return decoder.decode()
+}
+
+// the BOM is not part of any rune, so callers tracking byte offsets
+// need to skip it before the first rune to know where that rune starts
+func(decoder *RuneDecoder) SkipByteOrderMark() (int, error) {
+	if decoder.started {
+		return 0, nil
+	}
+	decoder.started = true
+	head, err := decoder.source.Peek(4)
+	if err != nil && err != io.EOF {
+		return 0, err
+	}
+	for _, bom := range byteOrderMarks {
+		if decoder.encoding != ENC_DETECT && decoder.encoding != bom.encoding {
+			continue
+		}
+		if len(head) < len(bom.bytes) || string(head[:len(bom.bytes)]) != bom.bytes {
+			continue
+		}
+		decoder.encoding = bom.encoding
+		decoder.source.Discard(len(bom.bytes))
+		decoder.offset += uint64(len(bom.bytes))
+		return len(bom.bytes), nil
+	}
+	if decoder.encoding == ENC_DETECT {
+		decoder.encoding = ENC_UTF8
+	}
+	return 0, nil
+}
+
+func(decoder *RuneDecoder) peek(count int) ([]byte, error) {
+	bytes, err := decoder.source.Peek(count)
+	if err == io.EOF && len(bytes) > 0 {
+		return bytes, decoder.newError(bytes, true)
+	}
+	return bytes, err
+}
+
+func(decoder *RuneDecoder) consume(r rune, size int) (rune, int, error) {
+	decoder.source.Discard(size)
+	decoder.offset += uint64(size)
+	return r, size, nil
+}
+
+func(decoder *RuneDecoder) newError(bytes []byte, truncated bool) *EncodingError {
+	return &EncodingError {
+		Encoding: decoder.encoding,
+		Bytes: append([]byte(nil), bytes...),
+		ByteOffset: decoder.offset,
+		Truncated: truncated,
+	}
+}
+
+func(decoder *RuneDecoder) decode() (rune, int, error) {
+	switch decoder.encoding {
+		case ENC_UTF8:
+			return decoder.decodeUTF8()
+		case ENC_UTF16LE, ENC_UTF16BE:
+			return decoder.decodeUTF16(decoder.encoding == ENC_UTF16BE)
+		case ENC_UTF32LE, ENC_UTF32BE:
+			return decoder.decodeUTF32(decoder.encoding == ENC_UTF32BE)
+		case ENC_ASCII, ENC_LATIN1, ENC_WINDOWS1252:
+			return decoder.decodeSingleByte()
+		default:
+			return 0, 0, ErrUnknownEncoding
+	}
+}
+
+func(decoder *RuneDecoder) decodeUTF8() (rune, int, error) {
+	bytes, err := decoder.source.Peek(utf8.UTFMax)
+	if len(bytes) == 0 {
+		return 0, 0, err
+	}
+	if err != nil && err != io.EOF {
+		return 0, 0, err
+	}
+	if !utf8.FullRune(bytes) {
+		return 0, 0, decoder.newError(bytes, true)
+	}
+	r, size := utf8.DecodeRune(bytes)
+	if r == utf8.RuneError && size <= 1 {
+		return 0, 0, decoder.newError(bytes[:1], false)
+	}
+	return decoder.consume(r, size)
+}
+
+func decodeUnit16(bytes []byte, bigEndian bool) rune {
+	if bigEndian {
+		return rune(bytes[0]) << 8 | rune(bytes[1])
+	}
+	return rune(bytes[1]) << 8 | rune(bytes[0])
+}
+
+func(decoder *RuneDecoder) decodeUTF16(bigEndian bool) (rune, int, error) {
+	bytes, err := decoder.peek(2)
+	if err != nil {
+		return 0, 0, err
+	}
+	high := decodeUnit16(bytes, bigEndian)
+	if !utf16.IsSurrogate(high) {
+		return decoder.consume(high, 2)
+	}
+	if high >= 0xDC00 {
+		return 0, 0, decoder.newError(bytes, false)
+	}
+	bytes, err = decoder.peek(4)
+	if err != nil {
+		return 0, 0, err
+	}
+	r := utf16.DecodeRune(high, decodeUnit16(bytes[2:], bigEndian))
+	if r == utf8.RuneError {
+		return 0, 0, decoder.newError(bytes, false)
+	}
+	return decoder.consume(r, 4)
+}
+
+func(decoder *RuneDecoder) decodeUTF32(bigEndian bool) (rune, int, error) {
+	bytes, err := decoder.peek(4)
+	if err != nil {
+		return 0, 0, err
+	}
+	var r rune
+	if bigEndian {
+		r = rune(bytes[0]) << 24 | rune(bytes[1]) << 16 | rune(bytes[2]) << 8 | rune(bytes[3])
+	} else {
+		r = rune(bytes[3]) << 24 | rune(bytes[2]) << 16 | rune(bytes[1]) << 8 | rune(bytes[0])
+	}
+	if !utf8.ValidRune(r) {
+		return 0, 0, decoder.newError(bytes, false)
+	}
+	return decoder.consume(r, 4)
+}
+
+func(decoder *RuneDecoder) decodeSingleByte() (rune, int, error) {
+	bytes, err := decoder.peek(1)
+	if err != nil {
+		return 0, 0, err
+	}
+	r := rune(bytes[0])
+	switch {
+		case r < 0x80:
+		case decoder.encoding == ENC_ASCII:
+			return 0, 0, decoder.newError(bytes, false)
+		case decoder.encoding == ENC_WINDOWS1252 && r < 0xA0:
+			r = windows1252High[r - 0x80]
+			if r < 0 {
+				return 0, 0, decoder.newError(bytes, false)
+			}
+	}
+	return decoder.consume(r, 1)
+}
+
+func skipByteOrderMark(reader io.RuneReader) (int, error) {
+	if decoder, ok := reader.(*RuneDecoder); ok {
+		return decoder.SkipByteOrderMark()
+	}
+	return 0, nil
+}
+
+var _ io.RuneReader = &RuneDecoder{}
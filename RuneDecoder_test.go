@@ -0,0 +1,113 @@
+package gorecdesc
+
+import (
+	"io"
+	"bytes"
+	tst "testing"
+	. "github.com/UncleSniper/gotest"
+)
+
+func decodeAll(decoder *RuneDecoder) (string, []int, error) {
+	var runes []rune
+	var sizes []int
+	for {
+		r, size, err := decoder.ReadRune()
+		if err == io.EOF {
+			return string(runes), sizes, nil
+		}
+		if err != nil {
+			return string(runes), sizes, err
+		}
+		runes = append(runes, r)
+		sizes = append(sizes, size)
+	}
+}
+
+func TestRuneDecoderDetectsUTF16LE(t *tst.T) {
+	c := Use(t)
+	decoder := NewRuneDecoder(bytes.NewReader([]byte {0xFF, 0xFE, 'h', 0, 0x3D, 0xD8, 0x00, 0xDE}), ENC_DETECT)
+	text, sizes, err := decodeAll(decoder)
+	AssertThatError(c, err).Is(ZeroValue[error]())
+	AssertThat(c, text).Is(EqualTo("h\U0001F600"))
+	AssertThat(c, decoder.Encoding()).Is(EqualTo(ENC_UTF16LE))
+	AssertThat(c, sizes[0]).Is(EqualTo(2))
+	AssertThat(c, sizes[1]).Is(EqualTo(4))
+}
+
+func TestRuneDecoderDetectsUTF32BE(t *tst.T) {
+	c := Use(t)
+	decoder := NewRuneDecoder(bytes.NewReader([]byte {0, 0, 0xFE, 0xFF, 0, 0, 0, 'x'}), ENC_DETECT)
+	text, _, err := decodeAll(decoder)
+	AssertThatError(c, err).Is(ZeroValue[error]())
+	AssertThat(c, text).Is(EqualTo("x"))
+	AssertThat(c, decoder.Encoding()).Is(EqualTo(ENC_UTF32BE))
+}
+
+func TestRuneDecoderDefaultsToUTF8(t *tst.T) {
+	c := Use(t)
+	decoder := NewRuneDecoder(bytes.NewReader([]byte("grüß")), ENC_DETECT)
+	text, _, err := decodeAll(decoder)
+	AssertThatError(c, err).Is(ZeroValue[error]())
+	AssertThat(c, text).Is(EqualTo("grüß"))
+	AssertThat(c, decoder.Encoding()).Is(EqualTo(ENC_UTF8))
+}
+
+func TestRuneDecoderWindows1252(t *tst.T) {
+	c := Use(t)
+	decoder := NewRuneDecoder(bytes.NewReader([]byte {0x80, 'a', 0xE9}), ENC_WINDOWS1252)
+	text, _, err := decodeAll(decoder)
+	AssertThatError(c, err).Is(ZeroValue[error]())
+	AssertThat(c, text).Is(EqualTo("€aé"))
+}
+
+func TestRuneDecoderReportsInvalidUTF8(t *tst.T) {
+	c := Use(t)
+	decoder := NewRuneDecoder(bytes.NewReader([]byte {'a', 0xFF, 'b'}), ENC_UTF8)
+	text, _, err := decodeAll(decoder)
+	AssertThat(c, text).Is(EqualTo("a"))
+	AssertThatError(c, err).Has(ErrorWithMessage("Invalid UTF-8 byte sequence 0xFF at byte offset 1"))
+}
+
+func TestRuneDecoderReportsUnpairedSurrogate(t *tst.T) {
+	c := Use(t)
+	decoder := NewRuneDecoder(bytes.NewReader([]byte {0xD8, 0x00, 0x00, 'a'}), ENC_UTF16BE)
+	_, _, err := decodeAll(decoder)
+	AssertThatError(c, err).Has(ErrorWithMessage("Invalid UTF-16BE byte sequence 0xD8 0x00 0x00 0x61 at byte offset 0"))
+}
+
+func TestRuneDecoderReportsTruncatedInput(t *tst.T) {
+	c := Use(t)
+	decoder := NewRuneDecoder(bytes.NewReader([]byte {'a', 0, 'b'}), ENC_UTF16LE)
+	text, _, err := decodeAll(decoder)
+	AssertThat(c, text).Is(EqualTo("a"))
+	AssertThatError(c, err).Has(ErrorWithMessage("Truncated UTF-16LE byte sequence 0x62 at byte offset 2"))
+}
+
+func TestRuneDecoderReportsUnknownEncoding(t *tst.T) {
+	c := Use(t)
+	decoder := NewRuneDecoder(bytes.NewReader([]byte("abc")), Encoding(42))
+	text, _, err := decodeAll(decoder)
+	AssertThat(c, text).Is(EqualTo(""))
+	AssertThatError(c, err).Is(EqualTo(ErrUnknownEncoding))
+}
+
+func TestSendDecodedStartsAfterByteOrderMark(t *tst.T) {
+	c := Use(t)
+	var disp Dispatcher[testRune]
+	disp.SetBatchSize(16)
+	reader := disp.Subscribe()
+	sent := make(chan error)
+	go func() {
+		sent <- SendDecoded(&disp, bytes.NewReader([]byte("\xEF\xBB\xBFab")), ENC_DETECT, StartOfFile("test"))
+	}()
+	var offsets []uint64
+	for packet := reader.Next(); !packet.EOF; packet = reader.Next() {
+		offsets = append(offsets, packet.Item.Location.ByteOffset)
+	}
+	AssertThat(c, len(offsets)).Is(EqualTo(2))
+	AssertThat(c, offsets[0]).Is(EqualTo[uint64](3))
+	AssertThat(c, offsets[1]).Is(EqualTo[uint64](4))
+	reader.AcknowledgeOnChannel(ACK_UNSUBSCRIBE_ON_SUCCESS)
+	AssertThat(c, <-sent).Is(ZeroValue[error]())
+	disp.Close()
+}
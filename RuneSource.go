@@ -0,0 +1,145 @@
+package gorecdesc
+
+import (
+	"io"
+	"sync"
+)
+
+type runeSourceFrame struct {
+	reader io.RuneReader
+	location Location
+}
+
+type runeSourceInclude struct {
+	file string
+	reader io.RuneReader
+}
+
+type RuneSource struct {
+	SpliceAfter func(rune) bool
+	frames []*runeSourceFrame
+	includes []runeSourceInclude
+	lock sync.Mutex
+}
+
+func(source *RuneSource) Push(reader io.RuneReader, location Location) {
+	source.lock.Lock()
+	source.push(reader, location)
+	source.lock.Unlock()
+}
+
+func(source *RuneSource) push(reader io.RuneReader, location Location) {
+	if debugOn {
+		debugf("[RuneSource] Pushing input at %s on top of %d frames\n", location.Format(), len(source.frames))
+	}
+	source.frames = append(source.frames, &runeSourceFrame {
+		reader: reader,
+		location: location,
+	})
+}
+
+func(source *RuneSource) Include(file string, reader io.RuneReader) {
+	source.lock.Lock()
+	if debugOn {
+		debugf("[RuneSource] Queueing include of %s\n", file)
+	}
+	source.includes = append(source.includes, runeSourceInclude {
+		file: file,
+		reader: reader,
+	})
+	source.lock.Unlock()
+}
+
+func(source *RuneSource) Depth() int {
+	source.lock.Lock()
+	defer source.lock.Unlock()
+	return len(source.frames)
+}
+
+func(source *RuneSource) Location() Location {
+	source.lock.Lock()
+	defer source.lock.Unlock()
+	if len(source.frames) == 0 {
+		return Location{}
+	}
+	return source.frames[len(source.frames) - 1].location
+}
+
+func(source *RuneSource) isSplicePoint(r rune) bool {
+	if source.SpliceAfter == nil {
+		return r == '\n'
+	}
+	return source.SpliceAfter(r)
+}
+
+func(source *RuneSource) splice(disp *Dispatcher[Locatable[rune]], at Location) {
+	// the Readers have to be done with everything up to here first, so that an include
+	// asked for while reading it is spliced in right at this point and not any later
+	disp.Flush()
+	source.lock.Lock()
+	// the top frame is read first, so the first include goes on last
+	for index := len(source.includes) - 1; index >= 0; index-- {
+		include := source.includes[index]
+		source.push(include.reader, IncludedFile(include.file, at))
+	}
+	source.includes = nil
+	source.lock.Unlock()
+}
+
+func(source *RuneSource) Send(disp *Dispatcher[Locatable[rune]]) error {
+	var eofLocation Location
+	for {
+		source.lock.Lock()
+		if len(source.frames) == 0 {
+			source.lock.Unlock()
+			break
+		}
+		top := source.frames[len(source.frames) - 1]
+		source.lock.Unlock()
+		bomSize, err := skipByteOrderMark(top.reader)
+		if err != nil {
+			return err
+		}
+		if bomSize > 0 {
+			source.lock.Lock()
+			top.location.ByteOffset += uint64(bomSize)
+			source.lock.Unlock()
+		}
+		r, size, err := top.reader.ReadRune()
+		if err == io.EOF {
+			source.lock.Lock()
+			if debugOn {
+				debugf("[RuneSource] Popping input at %s\n", top.location.Format())
+			}
+			source.frames = source.frames[:len(source.frames) - 1]
+			source.lock.Unlock()
+			// the end of a file is a splice point, too
+			source.splice(disp, top.location)
+			if source.Depth() == 0 {
+				eofLocation = top.location
+			}
+			continue
+		}
+		if err != nil {
+			if encodingError, ok := err.(*EncodingError); ok {
+				encodingError.Location = top.location
+			}
+			return err
+		}
+		disp.Send(Locatable[rune] {
+			Symbol: r,
+			Location: top.location,
+		}, false)
+		if source.isSplicePoint(r) {
+			source.splice(disp, top.location)
+		}
+		source.lock.Lock()
+		top.location.NextRune(r, size)
+		source.lock.Unlock()
+	}
+	disp.Send(Locatable[rune] {
+		Symbol: '\x00',
+		Location: eofLocation,
+	}, true)
+	return nil
+}
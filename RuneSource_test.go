@@ -0,0 +1,144 @@
+package gorecdesc
+
+import (
+	"strings"
+	tst "testing"
+	. "github.com/UncleSniper/gotest"
+)
+
+type testIncludeFile struct {
+	name string
+	content string
+}
+
+func readIncluding(source *RuneSource, includes []testIncludeFile, batchSize uint) (string, map[rune]string) {
+	var disp Dispatcher[testRune]
+	disp.SetBatchSize(batchSize)
+	reader := disp.Subscribe()
+	sent := make(chan error)
+	go func() {
+		sent <- source.Send(&disp)
+	}()
+	var builder strings.Builder
+	locations := make(map[rune]string)
+	for packet := reader.Next(); !packet.EOF; packet = reader.Next() {
+		symbol := packet.Item.Symbol
+		builder.WriteRune(symbol)
+		locations[symbol] = packet.Item.Location.Format()
+		if symbol == '@' {
+			// the include directive must be acted on before reading past its splice point
+			source.Include(includes[0].name, strings.NewReader(includes[0].content))
+			includes = includes[1:]
+		}
+		reader.Acknowledge(ACK_KEEP_SUBSCRIPTION)
+	}
+	reader.AcknowledgeOnChannel(ACK_UNSUBSCRIBE_ON_SUCCESS)
+	<-sent
+	disp.Close()
+	return builder.String(), locations
+}
+
+func TestRuneSourceNestedInclude(t *tst.T) {
+	c := Use(t)
+	includes := []testIncludeFile {
+		testIncludeFile {"one", "x@y"},
+		testIncludeFile {"two", "z"},
+	}
+	forEachTestBatchSize(func(batchSize uint) {
+		source := RuneSource {
+			SpliceAfter: func(r rune) bool {
+				return r == '@'
+			},
+		}
+		source.Push(strings.NewReader("a@b"), StartOfFile("main"))
+		AssertThat(c, source.Depth()).Is(EqualTo(1))
+		text, locations := readIncluding(&source, includes, batchSize)
+		AssertThat(c, text).Is(EqualTo("a@x@zyb"))
+		AssertThat(c, source.Depth()).Is(EqualTo(0))
+		AssertThat(c, locations['x']).Is(EqualTo("one:1:1, included from main:1:2"))
+		AssertThat(c, locations['z']).Is(EqualTo("two:1:1, included from one:1:2, included from main:1:2"))
+		AssertThat(c, locations['y']).Is(EqualTo("one:1:3, included from main:1:2"))
+		AssertThat(c, locations['b']).Is(EqualTo("main:1:3"))
+	})
+}
+
+func TestRuneSourceIncludeInParser(t *tst.T) {
+	c := Use(t)
+	source := RuneSource {
+		SpliceAfter: func(r rune) bool {
+			return r == '@'
+		},
+	}
+	include := SingleToken[testRune, string](nil, "@", nil, func(packet *Packet[testRune]) bool {
+		if packet.EOF || packet.Item.Symbol != '@' {
+			return false
+		}
+		source.Include("inner", strings.NewReader("bc"))
+		return true
+	})
+	rule := Sequence[testRune, string, *Packet[testRune], string](
+		nil,
+		ConcatRunesAccu(),
+		testRuneToken('a'),
+		include,
+		testRuneToken('b'),
+		testRuneToken('c'),
+		testRuneToken('d'),
+	)
+	forEachTestBatchSize(func(batchSize uint) {
+		source.Push(strings.NewReader("a@d"), StartOfFile("main"))
+		var disp Dispatcher[testRune]
+		disp.SetBatchSize(batchSize)
+		reader := disp.Subscribe()
+		sent := make(chan error)
+		go func() {
+			sent <- source.Send(&disp)
+		}()
+		reader.Next()
+		result := RunRule(rule, reader)
+		result.Reader.AcknowledgeOnChannel(ACK_UNSUBSCRIBE_ON_SUCCESS)
+		disp.Close()
+		AssertThat(c, <-sent).Is(ZeroValue[error]())
+		AssertThat(c, result.Error == nil).Is(EqualTo(true))
+		AssertThat(c, result.Result).Is(EqualTo("a@bcd"))
+	})
+}
+
+func TestRuneSourceSplicesAfterLineEnd(t *tst.T) {
+	c := Use(t)
+	includes := []testIncludeFile {
+		testIncludeFile {"one", "x\n"},
+		testIncludeFile {"two", "y"},
+		testIncludeFile {"three", "z"},
+	}
+	forEachTestBatchSize(func(batchSize uint) {
+		var source RuneSource
+		source.Push(strings.NewReader("a@b\nc@\n@"), StartOfFile("main"))
+		text, locations := readIncluding(&source, includes, batchSize)
+		AssertThat(c, text).Is(EqualTo("a@b\nx\nc@\ny@z"))
+		AssertThat(c, locations['x']).Is(EqualTo("one:1:1, included from main:1:4"))
+		AssertThat(c, locations['y']).Is(EqualTo("two:1:1, included from main:2:3"))
+		// the end of the file is a splice point as well
+		AssertThat(c, locations['z']).Is(EqualTo("three:1:1, included from main:3:2"))
+	})
+}
+
+func TestRuneSourceKeepsBatching(t *tst.T) {
+	c := Use(t)
+	var source RuneSource
+	source.Push(strings.NewReader("abcdef"), StartOfFile("main"))
+	var disp Dispatcher[testRune]
+	disp.SetBatchSize(3)
+	reader := disp.Subscribe()
+	sent := make(chan error)
+	go func() {
+		sent <- source.Send(&disp)
+	}()
+	reader.Next()
+	// the whole first batch has been read before the Reader saw any of it
+	location := source.Location()
+	AssertThat(c, location.Format()).Is(EqualTo("main:1:3"))
+	reader.AcknowledgeOnChannel(ACK_UNSUBSCRIBE_ON_SUCCESS)
+	AssertThat(c, <-sent).Is(ZeroValue[error]())
+	disp.Close()
+}
@@ -0,0 +1,85 @@
+package gorecdesc
+
+import (
+	"fmt"
+)
+
+type Span struct {
+	Start Location
+	End Location
+}
+
+func(span *Span) ByteLength() uint64 {
+	if span.End.ByteOffset < span.Start.ByteOffset {
+		return 0
+	}
+	return span.End.ByteOffset - span.Start.ByteOffset
+}
+
+func(span *Span) RuneLength() uint64 {
+	if span.End.RuneOffset < span.Start.RuneOffset {
+		return 0
+	}
+	return span.End.RuneOffset - span.Start.RuneOffset
+}
+
+func(span *Span) Bytes(source []byte) []byte {
+	start, end := span.Start.ByteOffset, span.End.ByteOffset
+	if end > uint64(len(source)) {
+		end = uint64(len(source))
+	}
+	if start >= end {
+		return nil
+	}
+	return source[start:end]
+}
+
+func(span *Span) Text(source string) string {
+	start, end := span.Start.ByteOffset, span.End.ByteOffset
+	if end > uint64(len(source)) {
+		end = uint64(len(source))
+	}
+	if start >= end {
+		return ""
+	}
+	return source[start:end]
+}
+
+func(span *Span) Format() string {
+	start := span.Start.Format()
+	if span.Start.File != span.End.File || span.End.isEmpty() {
+		return start
+	}
+	switch {
+		case span.End.Line == 0:
+			return start
+		case span.End.Line == span.Start.Line && span.Start.Column > 0 && span.End.Column > 0:
+			return fmt.Sprintf("%s-%d", start, span.End.Column)
+		case span.End.Column > 0:
+			return fmt.Sprintf("%s-%d:%d", start, span.End.Line, span.End.Column)
+		default:
+			return fmt.Sprintf("%s-%d", start, span.End.Line)
+	}
+}
+
+func(locatable *RangeLocatable[SymbolT]) Span() Span {
+	return Span {
+		Start: locatable.Start,
+		End: locatable.End,
+	}
+}
+
+func Spanning[SymbolT any](symbol SymbolT, span Span) RangeLocatable[SymbolT] {
+	return RangeLocatable[SymbolT] {
+		Symbol: symbol,
+		Start: span.Start,
+		End: span.End,
+	}
+}
+
+func SpanBetween[StartT any, EndT any](start Locatable[StartT], end Locatable[EndT]) Span {
+	return Span {
+		Start: start.Location,
+		End: end.Location,
+	}
+}
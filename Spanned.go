@@ -0,0 +1,96 @@
+package gorecdesc
+
+type Spanned[ReadT any, OutT any] struct {
+	Value OutT
+	First *Packet[ReadT]
+	Last *Packet[ReadT]
+}
+
+func(spanned *Spanned[ReadT, OutT]) Consumed() bool {
+	return spanned.First != nil && spanned.Last != nil
+}
+
+func(spanned *Spanned[ReadT, OutT]) PacketCount() uint64 {
+	if !spanned.Consumed() {
+		return 0
+	}
+	return spanned.Last.Offset - spanned.First.Offset + 1
+}
+
+func WithSpan[ReadT any, OutT any, ExpectT any](
+	rule Rule[ReadT, OutT, ExpectT],
+) Rule[ReadT, Spanned[ReadT, OutT], ExpectT] {
+	return func(reader *Reader[ReadT], resultChannel ResultChannel[ReadT, Spanned[ReadT, OutT], ExpectT]) {
+		if debugOn {
+			debugf("Entering WithSpan with Reader %s\n", debugReader(reader))
+		}
+		start := reader.Current()
+		var result *Result[ReadT, Spanned[ReadT, OutT], ExpectT]
+		if rule == nil {
+			result = missingRuleResult[ReadT, Spanned[ReadT, OutT], ExpectT]("WithSpan", nil, reader)
+		} else {
+			// the trail lets Previous survive the inner rule handing packets back
+			reader.holdTrail()
+			innerResult := RunRule(rule, reader)
+			var first, last *Packet[ReadT]
+			if innerResult.Error == nil && innerResult.Reader.Current().Offset > start.Offset {
+				first, last = start, innerResult.Reader.Previous()
+			}
+			innerResult.Reader.releaseTrail()
+			result = MapResult(innerResult, func(value OutT) Spanned[ReadT, OutT] {
+				return Spanned[ReadT, OutT] {
+					Value: value,
+					First: first,
+					Last: last,
+				}
+			})
+		}
+		if debugOn {
+			debugf("[WithSpan with Reader %s] Issuing %s\n", debugReader(reader), debugResult(result))
+		}
+		resultChannel <- result
+		if debugOn {
+			debugf("Leaving WithSpan with Reader %s\n", debugReader(reader))
+		}
+	}
+}
+
+func WithLocatableSpan[SymbolT any, OutT any, ExpectT any](
+	rule Rule[Locatable[SymbolT], OutT, ExpectT],
+) Rule[Locatable[SymbolT], RangeLocatable[OutT], ExpectT] {
+	spannedRule := WithSpan(rule)
+	return func(
+		reader *Reader[Locatable[SymbolT]],
+		resultChannel ResultChannel[Locatable[SymbolT], RangeLocatable[OutT], ExpectT],
+	) {
+		if debugOn {
+			debugf("Entering WithLocatableSpan with Reader %s\n", debugReader(reader))
+		}
+		spannedResult := RunRule(spannedRule, reader)
+		var result *Result[Locatable[SymbolT], RangeLocatable[OutT], ExpectT]
+		if spannedResult.Error != nil {
+			var none RangeLocatable[OutT]
+			result = SubstResult(spannedResult, none)
+		} else {
+			// the range ends where the next packet starts, so that it covers the last consumed one
+			spanned := spannedResult.Result
+			end := spannedResult.Reader.Current().Item.Location
+			start := end
+			if spanned.Consumed() {
+				start = spanned.First.Item.Location
+			}
+			result = SubstResult(spannedResult, RangeLocatable[OutT] {
+				Symbol: spanned.Value,
+				Start: start,
+				End: end,
+			})
+		}
+		if debugOn {
+			debugf("[WithLocatableSpan with Reader %s] Issuing %s\n", debugReader(reader), debugResult(result))
+		}
+		resultChannel <- result
+		if debugOn {
+			debugf("Leaving WithLocatableSpan with Reader %s\n", debugReader(reader))
+		}
+	}
+}
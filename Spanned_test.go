@@ -0,0 +1,130 @@
+package gorecdesc
+
+import (
+	tst "testing"
+	. "github.com/UncleSniper/gotest"
+)
+
+func TestWithSpan(t *tst.T) {
+	c := Use(t)
+	rule := WithSpan(Sequence[testRune, int, *Packet[testRune], string](
+		The(0),
+		testCount[*Packet[testRune]],
+		testRuneToken('a'),
+		testRuneToken('b'),
+	))
+	forEachTestBatchSize(func(batchSize uint) {
+		result, _ := parseTestInput(rule, "abx", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(true))
+		AssertThat(c, result.Result.Value).Is(EqualTo(2))
+		AssertThat(c, result.Result.First.Item.Symbol).Is(EqualTo('a'))
+		AssertThat(c, result.Result.Last.Item.Symbol).Is(EqualTo('b'))
+		AssertThat(c, result.Result.PacketCount()).Is(EqualTo[uint64](2))
+		result, _ = parseTestInput(rule, "ax", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(false))
+		AssertThat(c, result.Result.Consumed()).Is(EqualTo(false))
+	})
+}
+
+func TestWithSpanConsumingNothing(t *tst.T) {
+	c := Use(t)
+	rule := WithSpan(Option[testRune, *Packet[testRune], string](nil, nil, testRuneToken('a')))
+	result, _ := parseTestInput(rule, "b", 1)
+	AssertThat(c, result.Error == nil).Is(EqualTo(true))
+	AssertThat(c, result.Result.Consumed()).Is(EqualTo(false))
+	AssertThat(c, result.Result.PacketCount()).Is(EqualTo[uint64](0))
+}
+
+func TestWithSpanAfterChoice(t *tst.T) {
+	c := Use(t)
+	pair := func(second rune) Rule[testRune, int, string] {
+		return Sequence[testRune, int, *Packet[testRune], string](
+			The(0),
+			testCount[*Packet[testRune]],
+			testRuneToken('a'),
+			testRuneToken(second),
+		)
+	}
+	rule := Seq2(
+		WithSpan(Choice[testRune, int, string]("", nil, "", nil, nil, nil, pair('b'), pair('c'))),
+		testRuneToken('x'),
+	)
+	forEachTestBatchSize(func(batchSize uint) {
+		result, _ := parseTestInput(rule, "acx", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(true))
+		AssertThat(c, result.Result.First.Last.Item.Symbol).Is(EqualTo('c'))
+		AssertThat(c, result.Result.First.Last.Offset).Is(EqualTo[uint64](1))
+	})
+}
+
+func TestWithSpanAfterLookahead(t *tst.T) {
+	c := Use(t)
+	rule := WithSpan(Keyword[string](nil, "", nil, "if"))
+	forEachTestBatchSize(func(batchSize uint) {
+		result, _ := parseTestInput(rule, "if(", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(true))
+		AssertThat(c, result.Result.Last.Item.Symbol).Is(EqualTo('f'))
+		AssertThat(c, result.Result.PacketCount()).Is(EqualTo[uint64](2))
+	})
+}
+
+func TestWithLocatableSpan(t *tst.T) {
+	c := Use(t)
+	rule := WithLocatableSpan(Sequence[testRune, int, *Packet[testRune], string](
+		The(0),
+		testCount[*Packet[testRune]],
+		testRuneToken('a'),
+		testRuneToken('b'),
+	))
+	forEachTestBatchSize(func(batchSize uint) {
+		result, _ := parseTestInput(rule, "abx", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(true))
+		AssertThat(c, result.Result.Start.RuneOffset).Is(EqualTo[uint64](0))
+		AssertThat(c, result.Result.End.RuneOffset).Is(EqualTo[uint64](2))
+		span := result.Result.Span()
+		AssertThat(c, span.Text("abx")).Is(EqualTo("ab"))
+		result, _ = parseTestInput(rule, "ax", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(false))
+		AssertThat(c, result.Result.Symbol).Is(EqualTo(0))
+		AssertThat(c, result.Result.End.RuneOffset).Is(EqualTo[uint64](0))
+	})
+}
+
+func TestWithSpanAfterReprovide(t *tst.T) {
+	c := Use(t)
+	// reads three packets and hands the last two back, like a hand-written lookahead would
+	var pushBack Rule[testRune, int, string] = func(
+		reader *Reader[testRune],
+		resultChannel ResultChannel[testRune, int, string],
+	) {
+		var consumed []*Packet[testRune]
+		for len(consumed) < 3 {
+			consumed = append(consumed, reader.Current())
+			reader.Acknowledge(ACK_KEEP_SUBSCRIPTION)
+			reader.Next()
+		}
+		reader.Reprovide(consumed[1:], true)
+		resultChannel <- &Result[testRune, int, string] {
+			Offset: reader.Current().Offset,
+			Result: 1,
+			Reader: reader,
+		}
+	}
+	rule := Seq2(testRuneToken('x'), WithSpan(Seq2(testRuneToken('a'), pushBack)))
+	forEachTestBatchSize(func(batchSize uint) {
+		result, _ := parseTestInput(rule, "xabcd", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(true))
+		AssertThat(c, result.Result.Second.First.Item.Symbol).Is(EqualTo('a'))
+		AssertThat(c, result.Result.Second.Last.Item.Symbol).Is(EqualTo('b'))
+		AssertThat(c, result.Result.Second.PacketCount()).Is(EqualTo[uint64](2))
+		AssertThat(c, result.Reader.Previous().Item.Symbol).Is(EqualTo('b'))
+	})
+}
+
+func TestWithSpanWithoutRule(t *tst.T) {
+	c := Use(t)
+	rule := WithSpan[testRune, int, string](nil)
+	result, _ := parseTestInput(rule, "a", 1)
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected... something near 'a' at test:1:1"))
+	AssertThat(c, result.Result.Consumed()).Is(EqualTo(false))
+}
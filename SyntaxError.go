@@ -12,6 +12,8 @@ type SyntaxError[ReadT any, ExpectT any] struct {
 	Committed Commission
 	Structure string
 	ChoiceErrors []ParseError[ReadT, ExpectT]
+	Cause error
+	Related []RelatedPacket[ReadT]
 }
 
 func(err *SyntaxError[ReadT, ExpectT]) Start() *Packet[ReadT] {
@@ -41,16 +43,27 @@ func(err *SyntaxError[ReadT, ExpectT]) SubErrors() []ParseError[ReadT, ExpectT]
 	return err.ChoiceErrors
 }
 
-func(err *SyntaxError[ReadT, ExpectT]) Error() string {
-	var builder strings.Builder
+func(err *SyntaxError[ReadT, ExpectT]) OfferRelated(related RelatedPacket[ReadT]) {
+	err.Related = append(err.Related, related)
+}
+
+func(err *SyntaxError[ReadT, ExpectT]) RelatedPackets() []RelatedPacket[ReadT] {
+	return err.Related
+}
+
+func(err *SyntaxError[ReadT, ExpectT]) writeExpected(builder *strings.Builder) {
 	builder.WriteString("Expected")
-	if len(err.Expected) == 0 || err.FormatExpected == nil {
+	if len(err.Expected) == 0 {
 		builder.WriteString("... something")
 	} else {
+		formatExpected := err.FormatExpected
+		if formatExpected == nil {
+			formatExpected = FormatExpected[ExpectT]
+		}
 		had := false
 		var previousRendition string
 		for _, expectation := range err.Expected {
-			rendition := err.FormatExpected(expectation)
+			rendition := formatExpected(expectation)
 			if len(rendition) == 0 {
 				continue
 			}
@@ -74,8 +87,30 @@ func(err *SyntaxError[ReadT, ExpectT]) Error() string {
 			builder.WriteString(previousRendition)
 		}
 	}
-	if err.Found != nil && err.FormatFound != nil {
-		rendition := err.FormatFound(err.Found)
+}
+
+func(err *SyntaxError[ReadT, ExpectT]) Unwrap() error {
+	return err.Cause
+}
+
+func(err *SyntaxError[ReadT, ExpectT]) Error() string {
+	var builder strings.Builder
+	if err.Cause != nil && len(err.Expected) == 0 {
+		builder.WriteString(err.Cause.Error())
+	} else {
+		err.writeExpected(&builder)
+		if err.Cause != nil {
+			builder.WriteString(" (")
+			builder.WriteString(err.Cause.Error())
+			builder.WriteString(")")
+		}
+	}
+	if err.Found != nil {
+		formatFound := err.FormatFound
+		if formatFound == nil {
+			formatFound = FormatPacket[ReadT]
+		}
+		rendition := formatFound(err.Found)
 		if len(rendition) > 0 {
 			builder.WriteString(" near ")
 			builder.WriteString(rendition)
@@ -94,6 +129,7 @@ func(err *SyntaxError[ReadT, ExpectT]) Error() string {
 		}
 		builder.WriteString(err.Structure)
 	}
+	writeRelated(&builder, err.Related)
 	return builder.String()
 }
 
@@ -37,3 +37,171 @@ func BypassBiAccu[
 		return accumulator
 	}
 }
+
+func EmptySlice[ElementT any]() InitAccu[[]ElementT] {
+	return func() []ElementT {
+		return nil
+	}
+}
+
+func EmptyMap[KeyT comparable, ValueT any]() InitAccu[map[KeyT]ValueT] {
+	return func() map[KeyT]ValueT {
+		return make(map[KeyT]ValueT)
+	}
+}
+
+func AppendAccu[PieceT any]() CombineAccu[[]PieceT, PieceT] {
+	return func(accumulator []PieceT, piece PieceT) []PieceT {
+		return append(accumulator, piece)
+	}
+}
+
+// Repetition pairs a trailing separator with a zero item, which is appended like any other;
+// use RepetitionWithTrailing to keep it out
+func AppendItemsBiAccu[SeparatorT any, ItemT any]() CombineBiAccu[[]ItemT, SeparatorT, ItemT] {
+	return func(accumulator []ItemT, separator SeparatorT, item ItemT) []ItemT {
+		return append(accumulator, item)
+	}
+}
+
+type Separated[SeparatorT any, ItemT any] struct {
+	Items []ItemT
+	Separators []SeparatorT
+}
+
+func EmptySeparated[SeparatorT any, ItemT any]() InitAccu[*Separated[SeparatorT, ItemT]] {
+	return func() *Separated[SeparatorT, ItemT] {
+		return &Separated[SeparatorT, ItemT] {}
+	}
+}
+
+func AppendSeparatedBiAccu[
+	SeparatorT any,
+	ItemT any,
+]() CombineBiAccu[*Separated[SeparatorT, ItemT], SeparatorT, ItemT] {
+	return func(
+		accumulator *Separated[SeparatorT, ItemT],
+		separator SeparatorT,
+		item ItemT,
+	) *Separated[SeparatorT, ItemT] {
+		if accumulator == nil {
+			accumulator = &Separated[SeparatorT, ItemT] {}
+		}
+		// the first item is not preceded by a separator
+		if len(accumulator.Items) > 0 {
+			accumulator.Separators = append(accumulator.Separators, separator)
+		}
+		accumulator.Items = append(accumulator.Items, item)
+		return accumulator
+	}
+}
+
+func AppendTrailingSeparatorAccu[
+	SeparatorT any,
+	ItemT any,
+]() CombineAccu[*Separated[SeparatorT, ItemT], SeparatorT] {
+	return func(accumulator *Separated[SeparatorT, ItemT], separator SeparatorT) *Separated[SeparatorT, ItemT] {
+		if accumulator == nil {
+			accumulator = &Separated[SeparatorT, ItemT] {}
+		}
+		accumulator.Separators = append(accumulator.Separators, separator)
+		return accumulator
+	}
+}
+
+func ConcatAccu[PieceT any](format func(PieceT) string) CombineAccu[string, PieceT] {
+	return func(accumulator string, piece PieceT) string {
+		if format == nil {
+			return accumulator
+		}
+		return accumulator + format(piece)
+	}
+}
+
+func ConcatRunesAccu() CombineAccu[string, *Packet[Locatable[rune]]] {
+	return ConcatAccu(func(packet *Packet[Locatable[rune]]) string {
+		if packet == nil || packet.EOF {
+			return ""
+		}
+		return string(packet.Item.Symbol)
+	})
+}
+
+func PutAccu[KeyT comparable, ValueT any, PieceT any](
+	entry func(PieceT) (KeyT, ValueT),
+) CombineAccu[map[KeyT]ValueT, PieceT] {
+	return func(accumulator map[KeyT]ValueT, piece PieceT) map[KeyT]ValueT {
+		if entry == nil {
+			return accumulator
+		}
+		if accumulator == nil {
+			accumulator = make(map[KeyT]ValueT)
+		}
+		key, value := entry(piece)
+		accumulator[key] = value
+		return accumulator
+	}
+}
+
+func PutPairAccu[KeyT comparable, ValueT any]() CombineAccu[map[KeyT]ValueT, Tuple2[KeyT, ValueT]] {
+	return PutAccu(func(pair Tuple2[KeyT, ValueT]) (KeyT, ValueT) {
+		return pair.First, pair.Second
+	})
+}
+
+type Folded[ValueT any] struct {
+	Value ValueT
+	Started bool
+}
+
+// like AppendItemsBiAccu, this folds in the zero operand Repetition pairs with a trailing separator;
+// use RepetitionWithTrailing to keep it out
+func FoldLeftBiAccu[OperandT any, OperatorT any](
+	apply func(OperandT, OperatorT, OperandT) OperandT,
+) CombineBiAccu[Folded[OperandT], OperatorT, OperandT] {
+	return func(accumulator Folded[OperandT], operator OperatorT, operand OperandT) Folded[OperandT] {
+		// the first operand is not preceded by an operator
+		if !accumulator.Started || apply == nil {
+			return Folded[OperandT] {
+				Value: operand,
+				Started: true,
+			}
+		}
+		return Folded[OperandT] {
+			Value: apply(accumulator.Value, operator, operand),
+			Started: true,
+		}
+	}
+}
+
+type TryCombineAccu[AccumulatorT any, PieceT any] func(AccumulatorT, PieceT) (AccumulatorT, error)
+
+type TryCombineBiAccu[
+	AccumulatorT any,
+	LeftPieceT any,
+	RightPieceT any,
+] func(AccumulatorT, LeftPieceT, RightPieceT) (AccumulatorT, error)
+
+func TryAccu[AccumulatorT any, PieceT any](
+	combine CombineAccu[AccumulatorT, PieceT],
+) TryCombineAccu[AccumulatorT, PieceT] {
+	if combine == nil {
+		return nil
+	}
+	return func(accumulator AccumulatorT, piece PieceT) (AccumulatorT, error) {
+		return combine(accumulator, piece), nil
+	}
+}
+
+func TryBiAccu[
+	AccumulatorT any,
+	LeftPieceT any,
+	RightPieceT any,
+](combine CombineBiAccu[AccumulatorT, LeftPieceT, RightPieceT]) TryCombineBiAccu[AccumulatorT, LeftPieceT, RightPieceT] {
+	if combine == nil {
+		return nil
+	}
+	return func(accumulator AccumulatorT, left LeftPieceT, right RightPieceT) (AccumulatorT, error) {
+		return combine(accumulator, left, right), nil
+	}
+}
@@ -37,3 +37,194 @@ func TestBypassBiAccu(t *tst.T) {
 	AssertThat(c, theLeft).Is(EqualTo(42))
 	AssertThat(c, theRight).Is(EqualTo[uint64](123))
 }
+
+func TestAppendAccu(t *tst.T) {
+	c := Use(t)
+	appender := AppendAccu[int]()
+	accu := EmptySlice[int]()()
+	accu = appender(accu, 1)
+	accu = appender(accu, 2)
+	AssertThat(c, len(accu)).Is(EqualTo(2))
+	AssertThat(c, accu[0]).Is(EqualTo(1))
+	AssertThat(c, accu[1]).Is(EqualTo(2))
+}
+
+func TestAppendItemsBiAccu(t *tst.T) {
+	c := Use(t)
+	appender := AppendItemsBiAccu[string, int]()
+	accu := appender(nil, "", 1)
+	accu = appender(accu, ",", 2)
+	AssertThat(c, len(accu)).Is(EqualTo(2))
+	AssertThat(c, accu[0]).Is(EqualTo(1))
+	AssertThat(c, accu[1]).Is(EqualTo(2))
+}
+
+func TestAppendSeparatedBiAccu(t *tst.T) {
+	c := Use(t)
+	appender := AppendSeparatedBiAccu[string, int]()
+	accu := EmptySeparated[string, int]()()
+	accu = appender(accu, "", 1)
+	accu = appender(accu, ",", 2)
+	accu = appender(accu, ";", 3)
+	AssertThat(c, len(accu.Items)).Is(EqualTo(3))
+	AssertThat(c, accu.Items[0]).Is(EqualTo(1))
+	AssertThat(c, accu.Items[1]).Is(EqualTo(2))
+	AssertThat(c, accu.Items[2]).Is(EqualTo(3))
+	AssertThat(c, len(accu.Separators)).Is(EqualTo(2))
+	AssertThat(c, accu.Separators[0]).Is(EqualTo(","))
+	AssertThat(c, accu.Separators[1]).Is(EqualTo(";"))
+}
+
+func TestConcatRunesAccu(t *tst.T) {
+	c := Use(t)
+	rule := Sequence[testRune, string, *Packet[testRune], string](
+		nil,
+		ConcatRunesAccu(),
+		testRuneToken('a'),
+		testRuneToken('b'),
+		testRuneToken('c'),
+	)
+	result, _ := parseTestInput(rule, "abc", 2)
+	AssertThat(c, result.Error == nil).Is(EqualTo(true))
+	AssertThat(c, result.Result).Is(EqualTo("abc"))
+}
+
+func TestPutPairAccu(t *tst.T) {
+	c := Use(t)
+	putter := PutPairAccu[string, int]()
+	accu := EmptyMap[string, int]()()
+	accu = putter(accu, MakeTuple2("foo", 1))
+	accu = putter(accu, MakeTuple2("bar", 2))
+	accu = putter(accu, MakeTuple2("foo", 3))
+	AssertThat(c, len(accu)).Is(EqualTo(2))
+	AssertThat(c, accu["foo"]).Is(EqualTo(3))
+	AssertThat(c, accu["bar"]).Is(EqualTo(2))
+}
+
+func testDigit() Rule[testRune, int, string] {
+	return MapRule[testRune, *Packet[testRune], int, string](
+		nil,
+		"",
+		nil,
+		SingleToken[testRune, string](nil, "digit", nil, TokenPredicate(func(item testRune) bool {
+			return item.Symbol >= '0' && item.Symbol <= '9'
+		})),
+		func(packet *Packet[testRune]) int {
+			// MapRule maps failed results, too
+			if packet == nil {
+				return 0
+			}
+			return int(packet.Item.Symbol - '0')
+		},
+	)
+}
+
+func TestFoldLeftBiAccu(t *tst.T) {
+	c := Use(t)
+	digit := testDigit()
+	rule := Repetition[testRune, Folded[int], int, *Packet[testRune], string](
+		nil,
+		nil,
+		FoldLeftBiAccu(func(left int, operator *Packet[testRune], right int) int {
+			return left - right
+		}),
+		"",
+		nil,
+		digit,
+		testRuneToken('-'),
+		1,
+		10,
+		false,
+	)
+	result, _ := parseTestInput(rule, "9-2-3", 1)
+	AssertThat(c, result.Error == nil).Is(EqualTo(true))
+	AssertThat(c, result.Result.Value).Is(EqualTo(4))
+}
+
+func TestAccumulatorsWithTrailingSeparator(t *tst.T) {
+	c := Use(t)
+	// plain Repetition hands the trailing separator over with a zero item
+	items := Repetition[testRune, []int, int, *Packet[testRune], string](
+		nil,
+		nil,
+		AppendItemsBiAccu[*Packet[testRune], int](),
+		"",
+		nil,
+		testDigit(),
+		testRuneToken(','),
+		0,
+		10,
+		true,
+	)
+	result, _ := parseTestInput(items, "1,2,", 1)
+	AssertThat(c, result.Error == nil).Is(EqualTo(true))
+	AssertThat(c, len(result.Result)).Is(EqualTo(3))
+	AssertThat(c, result.Result[2]).Is(EqualTo(0))
+	items = RepetitionWithTrailing[testRune, []int, int, *Packet[testRune], string](
+		nil,
+		nil,
+		AppendItemsBiAccu[*Packet[testRune], int](),
+		nil,
+		"",
+		nil,
+		testDigit(),
+		testRuneToken(','),
+		0,
+		10,
+	)
+	forEachTestBatchSize(func(batchSize uint) {
+		result, _ := parseTestInput(items, "1,2,", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(true))
+		AssertThat(c, result.Offset).Is(EqualTo[uint64](4))
+		AssertThat(c, len(result.Result)).Is(EqualTo(2))
+		AssertThat(c, result.Result[0]).Is(EqualTo(1))
+		AssertThat(c, result.Result[1]).Is(EqualTo(2))
+	})
+	product := RepetitionWithTrailing[testRune, Folded[int], int, *Packet[testRune], string](
+		nil,
+		nil,
+		FoldLeftBiAccu(func(left int, operator *Packet[testRune], right int) int {
+			return left * right
+		}),
+		nil,
+		"",
+		nil,
+		testDigit(),
+		testRuneToken('*'),
+		1,
+		10,
+	)
+	forEachTestBatchSize(func(batchSize uint) {
+		result, _ := parseTestInput(product, "2*3*", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(true))
+		AssertThat(c, result.Result.Value).Is(EqualTo(6))
+	})
+	separated := RepetitionWithTrailing[
+		testRune,
+		*Separated[*Packet[testRune], int],
+		int,
+		*Packet[testRune],
+		string,
+	](
+		nil,
+		EmptySeparated[*Packet[testRune], int](),
+		AppendSeparatedBiAccu[*Packet[testRune], int](),
+		AppendTrailingSeparatorAccu[*Packet[testRune], int](),
+		"",
+		nil,
+		testDigit(),
+		testRuneToken(','),
+		0,
+		10,
+	)
+	forEachTestBatchSize(func(batchSize uint) {
+		result, _ := parseTestInput(separated, "1,2,", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(true))
+		AssertThat(c, len(result.Result.Items)).Is(EqualTo(2))
+		AssertThat(c, len(result.Result.Separators)).Is(EqualTo(2))
+		AssertThat(c, result.Result.Separators[1].Item.Symbol).Is(EqualTo(','))
+		result, _ = parseTestInput(separated, "1,2", batchSize)
+		AssertThat(c, len(result.Result.Items)).Is(EqualTo(2))
+		AssertThat(c, len(result.Result.Separators)).Is(EqualTo(1))
+	})
+}
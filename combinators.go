@@ -24,6 +24,46 @@ func Sequence[ReadT any, AccumulatorT any, PieceT any, ExpectT any](
 	initAccu InitAccu[AccumulatorT],
 	combineAccu CombineAccu[AccumulatorT, PieceT],
 	children ...Rule[ReadT, PieceT, ExpectT],
+) Rule[ReadT, AccumulatorT, ExpectT] {
+	return TrySequence(nil, initAccu, TryAccu(combineAccu), children...)
+}
+
+func rejectPiece[ReadT any, AccumulatorT any, ExpectT any](
+	ruleName string,
+	formatPacket func(*Packet[ReadT]) string,
+	start *Packet[ReadT],
+	reader *Reader[ReadT],
+	accumulator AccumulatorT,
+	err error,
+) *Result[ReadT, AccumulatorT, ExpectT] {
+	// the piece itself was fine, so it is up to us to let go of the reader
+	if debugOn {
+		debugf(
+			"[%s with Reader %s] Accumulator rejected piece at packet %d: %s\n",
+			ruleName,
+			debugReader(reader),
+			start.Offset,
+			err.Error(),
+		)
+	}
+	reader.AcknowledgeOnChannel(ACK_UNSUBSCRIBE_ON_ERROR)
+	return &Result[ReadT, AccumulatorT, ExpectT] {
+		Offset: start.Offset,
+		Result: accumulator,
+		Error: &SyntaxError[ReadT, ExpectT] {
+			Found: start,
+			FormatFound: formatPacket,
+			Cause: err,
+		},
+		Reader: reader,
+	}
+}
+
+func TrySequence[ReadT any, AccumulatorT any, PieceT any, ExpectT any](
+	formatPacket func(*Packet[ReadT]) string,
+	initAccu InitAccu[AccumulatorT],
+	combineAccu TryCombineAccu[AccumulatorT, PieceT],
+	children ...Rule[ReadT, PieceT, ExpectT],
 ) Rule[ReadT, AccumulatorT, ExpectT] {
 	return func(reader *Reader[ReadT], resultChannel ResultChannel[ReadT, AccumulatorT, ExpectT]) {
 		if debugOn {
@@ -55,6 +95,7 @@ func Sequence[ReadT any, AccumulatorT any, PieceT any, ExpectT any](
 					childIndex,
 				)
 			}
+			start := reader.Current()
 			go child(reader, childResultChannel)
 			childResult := <-childResultChannel
 			if debugOn {
@@ -82,7 +123,30 @@ func Sequence[ReadT any, AccumulatorT any, PieceT any, ExpectT any](
 				return
 			}
 			if combineAccu != nil {
-				accumulator = combineAccu(accumulator, childResult.Result)
+				var err error
+				accumulator, err = combineAccu(accumulator, childResult.Result)
+				if err != nil {
+					outResult := rejectPiece[ReadT, AccumulatorT, ExpectT](
+						"Sequence",
+						formatPacket,
+						start,
+						childResult.Reader,
+						accumulator,
+						err,
+					)
+					if debugOn {
+						debugf(
+							"[Sequence with Reader %s] Issuing %s\n",
+							debugReader(reader),
+							debugResult(outResult),
+						)
+					}
+					resultChannel <- outResult
+					if debugOn {
+						debugf("Leaving Sequence with Reader %s\n", debugReader(reader))
+					}
+					return
+				}
 				if debugOn {
 					debugf(
 						"[Sequence with Reader %s] Updated accumulator to %+v\n",
@@ -203,6 +267,27 @@ func Choice[ReadT any, OutT any, ExpectT any](
 		}
 		parallel.Add(reader, choices[lowestChoiceIndex])
 		results := parallel.Await()
+		// the original Reader was added last, but its choice comes first
+		results = append(results[len(results) - 1:], results[:len(results) - 1]...)
+		if parallel.CutIndex() >= 0 {
+			// some choices committed themselves, so the others are out of the running
+			var committed []*Result[ReadT, OutT, ExpectT]
+			for resultIndex, result := range results {
+				if parallel.IsCut((resultIndex + len(results) - 1) % len(results)) {
+					committed = append(committed, result)
+				} else if result.Error == nil {
+					result.Reader.AcknowledgeOnChannel(ACK_UNSUBSCRIBE_ON_SUCCESS)
+				}
+			}
+			if debugOn {
+				debugf(
+					"[Choice with Reader %s] Considering only results after cut: %s\n",
+					debugReader(reader),
+					debugResultList(committed),
+				)
+			}
+			results = committed
+		}
 		if debugOn {
 			debugf(
 				"[Choice with Reader %s] Parallel.Await() returned results: %s\n",
@@ -301,10 +386,9 @@ func Choice[ReadT any, OutT any, ExpectT any](
 					if result.Error != nil {
 						continue
 					}
-					_, childStructure := result.Error.CommisionAndStructure()
 					if result.Offset == maxPositiveOffset {
 						ambChoices = append(ambChoices, AmbiguityChoice[ReadT, ExpectT] {
-							Structure: childStructure,
+							Structure: result.Structure,
 							EndBefore: result.Reader.Current(),
 						})
 					}
@@ -359,7 +443,7 @@ func Choice[ReadT any, OutT any, ExpectT any](
 		} else {
 			errors := make([]ParseError[ReadT, ExpectT], len(negativeResults))
 			var expectations [][]ExpectT
-			for resultIndex, result := range positiveResults {
+			for resultIndex, result := range negativeResults {
 				errors[resultIndex] = result.Error
 				expected := result.Error.Expectation()
 				if len(expected) > 0 {
@@ -409,6 +493,122 @@ func Repetition[ReadT any, AccumulatorT any, ItemT any, SeparatorT any, ExpectT
 	minItems uint64,
 	maxItems uint64,
 	allowTrailingSeparator bool,
+) Rule[ReadT, AccumulatorT, ExpectT] {
+	return TryRepetition(
+		formatPacket,
+		initAccu,
+		TryBiAccu(combineAccu),
+		noItem,
+		formatNoItem,
+		itemRule,
+		separatorRule,
+		minItems,
+		maxItems,
+		allowTrailingSeparator,
+	)
+}
+
+func TryRepetition[ReadT any, AccumulatorT any, ItemT any, SeparatorT any, ExpectT any](
+	formatPacket func(*Packet[ReadT]) string,
+	initAccu InitAccu[AccumulatorT],
+	combineAccu TryCombineBiAccu[AccumulatorT, SeparatorT, ItemT],
+	noItem ExpectT,
+	formatNoItem func(ExpectT) string,
+	itemRule Rule[ReadT, ItemT, ExpectT],
+	separatorRule Rule[ReadT, SeparatorT, ExpectT],
+	minItems uint64,
+	maxItems uint64,
+	allowTrailingSeparator bool,
+) Rule[ReadT, AccumulatorT, ExpectT] {
+	var combineTrailing TryCombineAccu[AccumulatorT, SeparatorT]
+	if combineAccu != nil {
+		// a trailing separator is combined with a zero item
+		combineTrailing = func(accumulator AccumulatorT, separator SeparatorT) (AccumulatorT, error) {
+			var emptyItem ItemT
+			return combineAccu(accumulator, separator, emptyItem)
+		}
+	}
+	return repetition(
+		formatPacket,
+		initAccu,
+		combineAccu,
+		combineTrailing,
+		noItem,
+		formatNoItem,
+		itemRule,
+		separatorRule,
+		minItems,
+		maxItems,
+		allowTrailingSeparator,
+	)
+}
+
+func RepetitionWithTrailing[ReadT any, AccumulatorT any, ItemT any, SeparatorT any, ExpectT any](
+	formatPacket func(*Packet[ReadT]) string,
+	initAccu InitAccu[AccumulatorT],
+	combineAccu CombineBiAccu[AccumulatorT, SeparatorT, ItemT],
+	combineTrailing CombineAccu[AccumulatorT, SeparatorT],
+	noItem ExpectT,
+	formatNoItem func(ExpectT) string,
+	itemRule Rule[ReadT, ItemT, ExpectT],
+	separatorRule Rule[ReadT, SeparatorT, ExpectT],
+	minItems uint64,
+	maxItems uint64,
+) Rule[ReadT, AccumulatorT, ExpectT] {
+	return TryRepetitionWithTrailing(
+		formatPacket,
+		initAccu,
+		TryBiAccu(combineAccu),
+		TryAccu(combineTrailing),
+		noItem,
+		formatNoItem,
+		itemRule,
+		separatorRule,
+		minItems,
+		maxItems,
+	)
+}
+
+func TryRepetitionWithTrailing[ReadT any, AccumulatorT any, ItemT any, SeparatorT any, ExpectT any](
+	formatPacket func(*Packet[ReadT]) string,
+	initAccu InitAccu[AccumulatorT],
+	combineAccu TryCombineBiAccu[AccumulatorT, SeparatorT, ItemT],
+	combineTrailing TryCombineAccu[AccumulatorT, SeparatorT],
+	noItem ExpectT,
+	formatNoItem func(ExpectT) string,
+	itemRule Rule[ReadT, ItemT, ExpectT],
+	separatorRule Rule[ReadT, SeparatorT, ExpectT],
+	minItems uint64,
+	maxItems uint64,
+) Rule[ReadT, AccumulatorT, ExpectT] {
+	// a trailing separator goes to combineTrailing alone; if that is nil, it is dropped
+	return repetition(
+		formatPacket,
+		initAccu,
+		combineAccu,
+		combineTrailing,
+		noItem,
+		formatNoItem,
+		itemRule,
+		separatorRule,
+		minItems,
+		maxItems,
+		true,
+	)
+}
+
+func repetition[ReadT any, AccumulatorT any, ItemT any, SeparatorT any, ExpectT any](
+	formatPacket func(*Packet[ReadT]) string,
+	initAccu InitAccu[AccumulatorT],
+	combineAccu TryCombineBiAccu[AccumulatorT, SeparatorT, ItemT],
+	combineTrailing TryCombineAccu[AccumulatorT, SeparatorT],
+	noItem ExpectT,
+	formatNoItem func(ExpectT) string,
+	itemRule Rule[ReadT, ItemT, ExpectT],
+	separatorRule Rule[ReadT, SeparatorT, ExpectT],
+	minItems uint64,
+	maxItems uint64,
+	allowTrailingSeparator bool,
 ) Rule[ReadT, AccumulatorT, ExpectT] {
 	return func(reader *Reader[ReadT], resultChannel ResultChannel[ReadT, AccumulatorT, ExpectT]) {
 		if debugOn {
@@ -457,6 +657,7 @@ func Repetition[ReadT any, AccumulatorT any, ItemT any, SeparatorT any, ExpectT
 		var haveItemCount uint64
 		separatorConsumed := false
 		var separatorValue SeparatorT
+		var separatorStart *Packet[ReadT]
 		var emptyItem ItemT
 		var emptySeparator SeparatorT
 		for {
@@ -473,7 +674,8 @@ func Repetition[ReadT any, AccumulatorT any, ItemT any, SeparatorT any, ExpectT
 				}
 				break
 			}
-			offsetBeforeItem := reader.Current().Offset
+			itemStart := reader.Current()
+			offsetBeforeItem := itemStart.Offset
 			var itemParallel Parallel[ReadT, ItemT, ExpectT]
 			split := reader.Split()
 			if debugOn {
@@ -502,9 +704,29 @@ func Repetition[ReadT any, AccumulatorT any, ItemT any, SeparatorT any, ExpectT
 			itemResult := itemResults[1]
 			if itemResult.Error != nil {
 				// we might actually get away with this
-				if haveItemCount >= minItems && (allowTrailingSeparator || !separatorConsumed) {
-					if haveItemCount > 0 && allowTrailingSeparator && separatorRule != nil && combineAccu != nil {
-						accumulator = combineAccu(accumulator, separatorValue, emptyItem)
+				if haveItemCount >= minItems && (allowTrailingSeparator || !separatorConsumed) && !itemParallel.IsCut(1) {
+					if haveItemCount > 0 && allowTrailingSeparator && separatorRule != nil && combineTrailing != nil {
+						var err error
+						accumulator, err = combineTrailing(accumulator, separatorValue)
+						if err != nil {
+							errResult := rejectPiece[ReadT, AccumulatorT, ExpectT](
+								"Repetition",
+								formatPacket,
+								separatorStart,
+								split,
+								accumulator,
+								err,
+							)
+							if debugOn {
+								debugf(
+									"[Repetition with Reader %s] Issuing %s due to trailing separator\n",
+									debugReader(reader),
+									debugResult(errResult),
+								)
+							}
+							resultChannel <- errResult
+							return
+						}
 						if debugOn {
 							debugf(
 								"[Repetition with Reader %s] Updated accumulator to %+v\n",
@@ -586,7 +808,28 @@ func Repetition[ReadT any, AccumulatorT any, ItemT any, SeparatorT any, ExpectT
 			}
 			reader = itemResult.Reader
 			if combineAccu != nil {
-				accumulator = combineAccu(accumulator, separatorValue, itemResult.Result)
+				var err error
+				accumulator, err = combineAccu(accumulator, separatorValue, itemResult.Result)
+				if err != nil {
+					errResult := rejectPiece[ReadT, AccumulatorT, ExpectT](
+						"Repetition",
+						formatPacket,
+						itemStart,
+						reader,
+						accumulator,
+						err,
+					)
+					if debugOn {
+						debugf(
+							"[Repetition with Reader %s] Issuing %s due to item #%d\n",
+							debugReader(reader),
+							debugResult(errResult),
+							haveItemCount,
+						)
+					}
+					resultChannel <- errResult
+					return
+				}
 				if debugOn {
 					debugf(
 						"[Repetition with Reader %s] Updated accumulator to %+v\n",
@@ -664,7 +907,8 @@ func Repetition[ReadT any, AccumulatorT any, ItemT any, SeparatorT any, ExpectT
 					haveItemCount - 1,
 				)
 			}
-			offsetBeforeSeparator := reader.Current().Offset
+			separatorStart = reader.Current()
+			offsetBeforeSeparator := separatorStart.Offset
 			var separatorParallel Parallel[ReadT, SeparatorT, ExpectT]
 			split = reader.Split()
 			if debugOn {
@@ -693,7 +937,7 @@ func Repetition[ReadT any, AccumulatorT any, ItemT any, SeparatorT any, ExpectT
 			separatorResult := separatorResults[1]
 			if separatorResult.Error != nil {
 				// we might actually get away with this
-				if haveItemCount >= minItems {
+				if haveItemCount >= minItems && !separatorParallel.IsCut(1) {
 					outResult := SubstResult[ReadT, SeparatorT, AccumulatorT, ExpectT](
 						separatorResults[0],
 						accumulator,
@@ -802,8 +1046,28 @@ func Repetition[ReadT any, AccumulatorT any, ItemT any, SeparatorT any, ExpectT
 						maxItems,
 					)
 				}
-				if combineAccu != nil {
-					accumulator = combineAccu(accumulator, separatorValue, emptyItem)
+				if combineTrailing != nil {
+					var err error
+					accumulator, err = combineTrailing(accumulator, separatorValue)
+					if err != nil {
+						errResult := rejectPiece[ReadT, AccumulatorT, ExpectT](
+							"Repetition",
+							formatPacket,
+							separatorStart,
+							reader,
+							accumulator,
+							err,
+						)
+						if debugOn {
+							debugf(
+								"[Repetition with Reader %s] Issuing %s due to trailing separator\n",
+								debugReader(reader),
+								debugResult(errResult),
+							)
+						}
+						resultChannel <- errResult
+						return
+					}
 					if debugOn {
 						debugf(
 							"[Repetition with Reader %s] Updated accumulator to %+v\n",
@@ -911,11 +1175,6 @@ func SingleToken[ReadT any, ExpectT any](
 				)
 			}
 			reader.Acknowledge(ACK_UNSUBSCRIBE_ON_ERROR)
-			if formatExpected == nil {
-				formatExpected = func(ExpectT) string {
-					return "<expected token not formattable>"
-				}
-			}
 			result = &Result[ReadT, *Packet[ReadT], ExpectT] {
 				Offset: current.Offset,
 				Error: &SyntaxError[ReadT, ExpectT] {
@@ -936,3 +1195,152 @@ func SingleToken[ReadT any, ExpectT any](
 		}
 	}
 }
+
+func Guard[ReadT any, ExpectT any](
+	formatPacket func(*Packet[ReadT]) string,
+	expected ExpectT,
+	formatExpected func(ExpectT) string,
+	predicate func(*Reader[ReadT]) bool,
+) Rule[ReadT, *Packet[ReadT], ExpectT] {
+	return func(reader *Reader[ReadT], resultChannel ResultChannel[ReadT, *Packet[ReadT], ExpectT]) {
+		if debugOn {
+			debugf("Entering Guard with Reader %s\n", debugReader(reader))
+		}
+		current := reader.Current()
+		var result *Result[ReadT, *Packet[ReadT], ExpectT]
+		if predicate == nil || predicate(reader) {
+			// pass, without consuming anything
+			result = &Result[ReadT, *Packet[ReadT], ExpectT] {
+				Offset: current.Offset,
+				Result: current,
+				Reader: reader,
+			}
+		} else {
+			if debugOn {
+				debugf("[Guard with Reader %s] Predicate failed, issuing ACK_UNSUBSCRIBE_ON_ERROR\n", debugReader(reader))
+			}
+			reader.Acknowledge(ACK_UNSUBSCRIBE_ON_ERROR)
+			result = &Result[ReadT, *Packet[ReadT], ExpectT] {
+				Offset: current.Offset,
+				Error: &SyntaxError[ReadT, ExpectT] {
+					Found: current,
+					Expected: []ExpectT {expected},
+					FormatFound: formatPacket,
+					FormatExpected: formatExpected,
+				},
+				Reader: reader,
+			}
+		}
+		if debugOn {
+			debugf("[Guard with Reader %s] Issuing %s\n", debugReader(reader), debugResult(result))
+		}
+		resultChannel <- result
+		if debugOn {
+			debugf("Leaving Guard with Reader %s\n", debugReader(reader))
+		}
+	}
+}
+
+func Named[ReadT any, OutT any, ExpectT any](
+	structure string,
+	rule Rule[ReadT, OutT, ExpectT],
+) Rule[ReadT, OutT, ExpectT] {
+	return func(reader *Reader[ReadT], resultChannel ResultChannel[ReadT, OutT, ExpectT]) {
+		if debugOn {
+			debugf("Entering Named for structure '%s' with Reader %s\n", structure, debugReader(reader))
+		}
+		start := reader.Current()
+		var result *Result[ReadT, OutT, ExpectT]
+		if rule == nil {
+			result = missingRuleResult[ReadT, OutT, ExpectT]("Named", nil, reader)
+		} else {
+			result = RunRule(rule, reader)
+		}
+		result.Structure = structure
+		if result.Error != nil {
+			// tell whether the structure had been entered before things went wrong;
+			// running out of input says nothing about how much of it is left
+			committed := COM_CONTINUE
+			near := result.Error.Near()
+			if near == nil || near.Offset <= start.Offset {
+				committed = COM_START
+			}
+			result.Error.OfferStructure(committed, structure)
+			if debugOn {
+				debugf(
+					"[Named for structure '%s' with Reader %s] Offered commission %d\n",
+					structure,
+					debugReader(reader),
+					committed,
+				)
+			}
+		}
+		if debugOn {
+			debugf(
+				"[Named for structure '%s' with Reader %s] Issuing %s\n",
+				structure,
+				debugReader(reader),
+				debugResult(result),
+			)
+		}
+		resultChannel <- result
+		if debugOn {
+			debugf("Leaving Named for structure '%s' with Reader %s\n", structure, debugReader(reader))
+		}
+	}
+}
+
+func Cut[ReadT any, OutT any, ExpectT any](returnValue OutT) Rule[ReadT, OutT, ExpectT] {
+	return func(reader *Reader[ReadT], resultChannel ResultChannel[ReadT, OutT, ExpectT]) {
+		if reader.cutMark != nil {
+			reader.cutMark.Store(true)
+		}
+		result := &Result[ReadT, OutT, ExpectT] {
+			Offset: reader.current.Offset,
+			Result: returnValue,
+			Reader: reader,
+		}
+		if debugOn {
+			debugf("Entering Cut with Reader %s\n", debugReader(reader))
+			debugf("[Cut with Reader %s] Issuing %s\n", debugReader(reader), debugResult(result))
+			debugf("Leaving Cut with Reader %s\n", debugReader(reader))
+		}
+		resultChannel <- result
+	}
+}
+
+func EndOfInput[ReadT any, ExpectT any](
+	formatPacket func(*Packet[ReadT]) string,
+	expected ExpectT,
+	formatExpected func(ExpectT) string,
+) Rule[ReadT, *Packet[ReadT], ExpectT] {
+	if formatExpected == nil {
+		formatExpected = func(ExpectT) string {
+			return "end of input"
+		}
+	}
+	return Guard(formatPacket, expected, formatExpected, func(reader *Reader[ReadT]) bool {
+		return reader.Current().EOF
+	})
+}
+
+func Complete[ReadT any, OutT any, ExpectT any](
+	formatPacket func(*Packet[ReadT]) string,
+	expected ExpectT,
+	formatExpected func(ExpectT) string,
+	rule Rule[ReadT, OutT, ExpectT],
+) Rule[ReadT, OutT, ExpectT] {
+	if rule == nil {
+		// Seq2Into would skip it and accept empty input
+		return func(reader *Reader[ReadT], resultChannel ResultChannel[ReadT, OutT, ExpectT]) {
+			resultChannel <- missingRuleResult[ReadT, OutT, ExpectT]("Complete", formatPacket, reader)
+		}
+	}
+	return Seq2Into(
+		func(value OutT, end *Packet[ReadT]) OutT {
+			return value
+		},
+		rule,
+		EndOfInput(formatPacket, expected, formatExpected),
+	)
+}
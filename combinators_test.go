@@ -0,0 +1,375 @@
+package gorecdesc
+
+import (
+	"errors"
+	"fmt"
+	tst "testing"
+	. "github.com/UncleSniper/gotest"
+)
+
+func TestGuard(t *tst.T) {
+	c := Use(t)
+	notAtX := Guard[testRune, string](nil, "anything but x", func(expected string) string {
+		return expected
+	}, func(reader *Reader[testRune]) bool {
+		return reader.Current().Item.Symbol != 'x'
+	})
+	rule := Sequence[testRune, int, *Packet[testRune], string](
+		The(0),
+		testCount[*Packet[testRune]],
+		notAtX,
+		testRuneToken('a'),
+	)
+	result, _ := parseTestInput(rule, "a", 1)
+	AssertThat(c, result.Error == nil).Is(EqualTo(true))
+	AssertThat(c, result.Offset).Is(EqualTo[uint64](1))
+	result, _ = parseTestInput(rule, "x", 1)
+	AssertThat(c, result.Offset).Is(EqualTo[uint64](0))
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected anything but x near 'x' at test:1:1"))
+}
+
+func testQuotedRuneToken(r rune) Rule[testRune, *Packet[testRune], string] {
+	return SingleToken[testRune, string](
+		nil,
+		string(r),
+		func(expected string) string {
+			return "'" + expected + "'"
+		},
+		TokenPredicate(func(item testRune) bool {
+			return item.Symbol == r
+		}),
+	)
+}
+
+func TestNamedCommission(t *tst.T) {
+	c := Use(t)
+	rule := Named("pair", Sequence[testRune, int, *Packet[testRune], string](
+		The(0),
+		testCount[*Packet[testRune]],
+		testQuotedRuneToken('('),
+		testQuotedRuneToken('a'),
+		testQuotedRuneToken(')'),
+	))
+	result, _ := parseTestInput(rule, "x", 1)
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected '(' near 'x' at test:1:1 to start pair"))
+	AssertThat(c, result.Structure).Is(EqualTo("pair"))
+	result, _ = parseTestInput(rule, "(x", 1)
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected 'a' near 'x' at test:1:2 to continue pair"))
+	result, _ = parseTestInput(rule, "(a", 1)
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected ')' near end of input at test:1:3 to continue pair"))
+	result, _ = parseTestInput(rule, "(", 1)
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected 'a' near end of input at test:1:2 to continue pair"))
+	result, _ = parseTestInput(rule, "(ax", 1)
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected ')' near 'x' at test:1:3 to continue pair"))
+	result, _ = parseTestInput(rule, "(a)", 1)
+	AssertThat(c, result.Error == nil).Is(EqualTo(true))
+	AssertThat(c, result.Structure).Is(EqualTo("pair"))
+}
+
+func TestChoiceAmbiguityNamesAlternatives(t *tst.T) {
+	c := Use(t)
+	rule := Choice[testRune, *Packet[testRune], string](
+		"letter",
+		nil,
+		"",
+		nil,
+		nil,
+		nil,
+		Named("first", testRuneToken('a')),
+		Named("second", testRuneToken('a')),
+	)
+	result, _ := parseTestInput(rule, "a", 1)
+	ambiguity, ok := result.Error.(*AmbiguityError[testRune, string])
+	AssertThat(c, ok).Is(EqualTo(true))
+	AssertThat(c, len(ambiguity.Choices)).Is(EqualTo(2))
+	AssertThat(c, ambiguity.Choices[0].Structure).Is(EqualTo("first"))
+	AssertThat(c, ambiguity.Choices[1].Structure).Is(EqualTo("second"))
+}
+
+func TestChoiceMergesNegativeExpectations(t *tst.T) {
+	c := Use(t)
+	rule := Choice[testRune, *Packet[testRune], string](
+		"",
+		nil,
+		"",
+		nil,
+		nil,
+		func(expected string) string {
+			return "'" + expected + "'"
+		},
+		testQuotedRuneToken('a'),
+		testQuotedRuneToken('b'),
+		testQuotedRuneToken('c'),
+	)
+	result, _ := parseTestInput(rule, "x", 1)
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected 'a', 'b', or 'c' near 'x' at test:1:1"))
+	AssertThat(c, len(result.Error.SubErrors())).Is(EqualTo(3))
+}
+
+func testStructured(
+	structure string,
+	rule Rule[testRune, *Packet[testRune], string],
+) Rule[testRune, *Packet[testRune], string] {
+	return func(reader *Reader[testRune], resultChannel ResultChannel[testRune, *Packet[testRune], string]) {
+		result := RunRule(rule, reader)
+		result.Structure = structure
+		resultChannel <- result
+	}
+}
+
+func TestChoiceKeepsDeclarationOrder(t *tst.T) {
+	c := Use(t)
+	rule := Choice[testRune, *Packet[testRune], string](
+		"",
+		nil,
+		"",
+		nil,
+		nil,
+		nil,
+		testQuotedRuneToken('a'),
+		testQuotedRuneToken('b'),
+		testQuotedRuneToken('c'),
+	)
+	forEachTestBatchSize(func(batchSize uint) {
+		result, _ := parseTestInput(rule, "x", batchSize)
+		subErrors := result.Error.SubErrors()
+		AssertThat(c, len(subErrors)).Is(EqualTo(3))
+		for index, expected := range []string {"a", "b", "c"} {
+			AssertThat(c, len(subErrors[index].Expectation())).Is(EqualTo(1))
+			AssertThat(c, subErrors[index].Expectation()[0]).Is(EqualTo(expected))
+		}
+	})
+}
+
+func TestChoiceAmbiguityTakesStructureFromResults(t *tst.T) {
+	c := Use(t)
+	rule := Choice[testRune, *Packet[testRune], string](
+		"letter",
+		nil,
+		"",
+		nil,
+		nil,
+		nil,
+		testStructured("lower", testRuneToken('a')),
+		testStructured("vowel", testRuneToken('a')),
+		testStructured("other", testRuneToken('b')),
+	)
+	forEachTestBatchSize(func(batchSize uint) {
+		result, _ := parseTestInput(rule, "a", batchSize)
+		ambiguity, ok := result.Error.(*AmbiguityError[testRune, string])
+		AssertThat(c, ok).Is(EqualTo(true))
+		AssertThat(c, ambiguity.Structure).Is(EqualTo("letter"))
+		AssertThat(c, len(ambiguity.Choices)).Is(EqualTo(2))
+		AssertThat(c, ambiguity.Choices[0].Structure).Is(EqualTo("lower"))
+		AssertThat(c, ambiguity.Choices[1].Structure).Is(EqualTo("vowel"))
+	})
+}
+
+func TestChoiceMergesExpectationsOfFailedChoicesOnly(t *tst.T) {
+	c := Use(t)
+	rule := Choice[testRune, *Packet[testRune], string](
+		"",
+		nil,
+		"",
+		nil,
+		nil,
+		func(expected string) string {
+			return "'" + expected + "'"
+		},
+		testQuotedRuneToken('a'),
+		testQuotedRuneToken('b'),
+	)
+	forEachTestBatchSize(func(batchSize uint) {
+		result, _ := parseTestInput(rule, "x", batchSize)
+		AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected 'a', or 'b' near 'x' at test:1:1"))
+		AssertThat(c, len(result.Error.Expectation())).Is(EqualTo(2))
+		result, _ = parseTestInput(rule, "b", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(true))
+		AssertThat(c, result.Offset).Is(EqualTo[uint64](1))
+	})
+}
+
+func TestCutInChoice(t *tst.T) {
+	c := Use(t)
+	cut := Cut[testRune, *Packet[testRune], string](nil)
+	function := Named("function", Sequence[testRune, int, *Packet[testRune], string](
+		The(0),
+		testCount[*Packet[testRune]],
+		testQuotedRuneToken('f'),
+		testQuotedRuneToken('n'),
+		cut,
+		testQuotedRuneToken('('),
+	))
+	letters := Repetition[testRune, int, *Packet[testRune], *Packet[testRune], string](
+		nil,
+		The(0),
+		func(count int, separator *Packet[testRune], item *Packet[testRune]) int {
+			return count + 1
+		},
+		"",
+		nil,
+		SingleToken[testRune, string](nil, "letter", nil, TokenPredicate(func(item testRune) bool {
+			return item.Symbol >= 'a' && item.Symbol <= 'z'
+		})),
+		nil,
+		1,
+		100,
+		false,
+	)
+	rule := Choice[testRune, int, string]("statement", nil, "", nil, nil, nil, function, letters)
+	forEachTestBatchSize(func(batchSize uint) {
+		result, _ := parseTestInput(rule, "fn(", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(true))
+		AssertThat(c, result.Result).Is(EqualTo(4))
+		result, _ = parseTestInput(rule, "fnord", batchSize)
+		AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected '(' near 'o' at test:1:3 to continue function"))
+		AssertThat(c, result.Offset).Is(EqualTo[uint64](2))
+		result, _ = parseTestInput(rule, "fxord", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(true))
+		AssertThat(c, result.Result).Is(EqualTo(5))
+	})
+}
+
+func TestCutInRepetition(t *tst.T) {
+	c := Use(t)
+	group := func(cut bool) Rule[testRune, int, string] {
+		var cutRule Rule[testRune, *Packet[testRune], string]
+		if cut {
+			cutRule = Cut[testRune, *Packet[testRune], string](nil)
+		}
+		return Sequence[testRune, int, *Packet[testRune], string](
+			The(0),
+			testCount[*Packet[testRune]],
+			testQuotedRuneToken('('),
+			cutRule,
+			testQuotedRuneToken('a'),
+			testQuotedRuneToken(')'),
+		)
+	}
+	groups := func(cut bool) Rule[testRune, int, string] {
+		return Repetition[testRune, int, int, *Packet[testRune], string](
+			nil,
+			The(0),
+			func(count int, separator *Packet[testRune], item int) int {
+				return count + 1
+			},
+			"",
+			nil,
+			group(cut),
+			nil,
+			0,
+			100,
+			false,
+		)
+	}
+	result, _ := parseTestInput(groups(false), "(a)(a", 1)
+	AssertThat(c, result.Error == nil).Is(EqualTo(true))
+	AssertThat(c, result.Offset).Is(EqualTo[uint64](3))
+	result, _ = parseTestInput(groups(true), "(a)(a", 1)
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected ')' near end of input at test:1:6"))
+	AssertThat(c, result.Offset).Is(EqualTo[uint64](5))
+}
+
+func TestTrySequenceRejectsPiece(t *tst.T) {
+	c := Use(t)
+	rule := TrySequence[testRune, string, *Packet[testRune], string](
+		nil,
+		nil,
+		func(letters string, packet *Packet[testRune]) (string, error) {
+			if len(letters) >= 2 {
+				return letters, errors.New("at most two letters allowed")
+			}
+			return letters + string(packet.Item.Symbol), nil
+		},
+		testRuneToken('a'),
+		testRuneToken('b'),
+		testRuneToken('c'),
+	)
+	forEachTestBatchSize(func(batchSize uint) {
+		result, stats := parseTestInput(rule, "abc", batchSize)
+		AssertThat[error](c, result.Error).Is(ErrorWithMessage("at most two letters allowed near 'c' at test:1:3"))
+		AssertThat(c, result.Offset).Is(EqualTo[uint64](2))
+		AssertThat(c, result.Result).Is(EqualTo("ab"))
+		AssertThat(c, stats.LiveSubscriptions).Is(EqualTo(0))
+	})
+}
+
+func TestTryRepetitionRejectsDuplicate(t *tst.T) {
+	c := Use(t)
+	letter := SingleToken[testRune, string](nil, "letter", nil, TokenPredicate(func(item testRune) bool {
+		return item.Symbol >= 'a' && item.Symbol <= 'z'
+	}))
+	rule := TryRepetition[testRune, map[rune]bool, *Packet[testRune], *Packet[testRune], string](
+		nil,
+		EmptyMap[rune, bool](),
+		func(seen map[rune]bool, separator *Packet[testRune], item *Packet[testRune]) (map[rune]bool, error) {
+			if seen[item.Item.Symbol] {
+				return seen, fmt.Errorf("duplicate %c", item.Item.Symbol)
+			}
+			seen[item.Item.Symbol] = true
+			return seen, nil
+		},
+		"",
+		nil,
+		letter,
+		testRuneToken(','),
+		0,
+		100,
+		false,
+	)
+	forEachTestBatchSize(func(batchSize uint) {
+		result, stats := parseTestInput(rule, "a,b,c", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(true))
+		AssertThat(c, len(result.Result)).Is(EqualTo(3))
+		result, stats = parseTestInput(rule, "a,b,a,c", batchSize)
+		AssertThat[error](c, result.Error).Is(ErrorWithMessage("duplicate a near 'a' at test:1:5"))
+		AssertThat(c, result.Offset).Is(EqualTo[uint64](4))
+		AssertThat(c, stats.LiveSubscriptions).Is(EqualTo(0))
+	})
+}
+
+func TestEndOfInput(t *tst.T) {
+	c := Use(t)
+	rule := EndOfInput[testRune, string](nil, "", nil)
+	result, _ := parseTestInput(rule, "", 1)
+	AssertThat(c, result.Error == nil).Is(EqualTo(true))
+	AssertThat(c, result.Result.EOF).Is(EqualTo(true))
+	result, _ = parseTestInput(rule, "a", 1)
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected end of input near 'a' at test:1:1"))
+}
+
+func TestComplete(t *tst.T) {
+	c := Use(t)
+	formatPacket := func(packet *Packet[testRune]) string {
+		if packet.EOF {
+			return "end of input"
+		}
+		return fmt.Sprintf("%q at %s", packet.Item.Symbol, packet.Item.Location.Format())
+	}
+	rule := Complete(formatPacket, "", nil, Many(nil, testQuotedRuneToken('a')))
+	forEachTestBatchSize(func(batchSize uint) {
+		result, stats := parseTestInput(rule, "aa", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(true))
+		AssertThat(c, len(result.Result)).Is(EqualTo(2))
+		AssertThat(c, stats.LiveSubscriptions).Is(EqualTo(0))
+		result, stats = parseTestInput(rule, "aab", batchSize)
+		AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected end of input near 'b' at test:1:3"))
+		AssertThat(c, result.Offset).Is(EqualTo[uint64](2))
+		AssertThat(c, stats.LiveSubscriptions).Is(EqualTo(0))
+	})
+}
+
+func TestNamedWithoutRule(t *tst.T) {
+	c := Use(t)
+	rule := Named[testRune, int, string]("pair", nil)
+	result, _ := parseTestInput(rule, "x", 1)
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected... something near 'x' at test:1:1 to start pair"))
+	AssertThat(c, result.Structure).Is(EqualTo("pair"))
+}
+
+func TestCompleteWithoutRule(t *tst.T) {
+	c := Use(t)
+	rule := Complete[testRune, int, string](nil, "end of input", nil, nil)
+	result, _ := parseTestInput(rule, "", 1)
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected... something near end of input at test:1:1"))
+}
@@ -65,6 +65,9 @@ func debugAck(ack Acknowledgement) string {
 }
 
 func debugPacket[ReadT any](packet *Packet[ReadT]) string {
+	if packet == nil {
+		return "Packet == nil"
+	}
 	return fmt.Sprintf("Packet { Offset = %d, Item = %+v, EOF = %v }", packet.Offset, packet.Item, packet.EOF)
 }
 
@@ -104,11 +107,14 @@ func debugPacketListList[ReadT any](prepended [][]*Packet[ReadT]) string {
 
 func debugReader[ReadT any](reader *Reader[ReadT]) string {
 	return fmt.Sprintf(
-		"%d (current = %s, prepended = %s, inPrepended = %d)",
+		"%d (current = %s, prepended = %s, inPrepended = %d, batch = %s, inBatch = %d, batchAcked = %v)",
 		reader.id,
 		debugPacket(reader.current),
 		debugPacketListList(reader.prepended),
 		reader.inPrepended,
+		debugPacketList(reader.batch),
+		reader.inBatch,
+		reader.batchAcked,
 	)
 }
 
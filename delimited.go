@@ -0,0 +1,74 @@
+package gorecdesc
+
+import (
+	"fmt"
+)
+
+func OpenedAt[SymbolT any](formatSymbol func(SymbolT) string) func(*Packet[Locatable[SymbolT]]) string {
+	return func(packet *Packet[Locatable[SymbolT]]) string {
+		if packet == nil {
+			return ""
+		}
+		var rendition string
+		if formatSymbol == nil {
+			rendition = fmt.Sprintf("%v", packet.Item.Symbol)
+		} else {
+			rendition = formatSymbol(packet.Item.Symbol)
+		}
+		return fmt.Sprintf("%s opened at %s", rendition, packet.Item.Location.Format())
+	}
+}
+
+func Delimited[ReadT any, OpenT any, OutT any, CloseT any, ExpectT any](
+	formatPacket func(*Packet[ReadT]) string,
+	describeOpen func(*Packet[ReadT]) string,
+	openRule Rule[ReadT, OpenT, ExpectT],
+	bodyRule Rule[ReadT, OutT, ExpectT],
+	closeRule Rule[ReadT, CloseT, ExpectT],
+) Rule[ReadT, OutT, ExpectT] {
+	if describeOpen == nil {
+		describeOpen = formatPacket
+	}
+	if describeOpen == nil {
+		describeOpen = FormatPacket[ReadT]
+	}
+	return func(reader *Reader[ReadT], resultChannel ResultChannel[ReadT, OutT, ExpectT]) {
+		if debugOn {
+			debugf("Entering Delimited with Reader %s\n", debugReader(reader))
+		}
+		open := reader.Current()
+		run := &seqRun[ReadT, OutT, ExpectT] {
+			name: "Delimited",
+			reader: reader,
+		}
+		runSeqChild(run, 0, openRule)
+		opened := run.failure == nil
+		bodyValue := runSeqChild(run, 1, bodyRule)
+		bodyFailed := run.failure != nil
+		runSeqChild(run, 2, closeRule)
+		if opened && run.failure != nil {
+			// a body that ran into the end of input is just as unclosed as a missing closer
+			near := run.failure.Error.Near()
+			if !bodyFailed || (near != nil && near.EOF) {
+				description := describeOpen(open)
+				if len(description) == 0 {
+					description = "delimiter"
+				}
+				if debugOn {
+					debugf(
+						"[Delimited with Reader %s] Marking error as unclosed %s\n",
+						debugReader(reader),
+						description,
+					)
+				}
+				run.failure.Error.OfferRelated(RelatedPacket[ReadT] {
+					Packet: open,
+					Note: "unclosed " + description,
+				})
+			}
+		}
+		run.issue(resultChannel, func() OutT {
+			return bodyValue
+		})
+	}
+}
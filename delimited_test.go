@@ -0,0 +1,50 @@
+package gorecdesc
+
+import (
+	"strconv"
+	tst "testing"
+	. "github.com/UncleSniper/gotest"
+)
+
+func TestDelimited(t *tst.T) {
+	c := Use(t)
+	rule := Delimited(
+		nil,
+		OpenedAt(strconv.QuoteRune),
+		testQuotedRuneToken('('),
+		Many(nil, testQuotedRuneToken('a')),
+		testQuotedRuneToken(')'),
+	)
+	forEachTestBatchSize(func(batchSize uint) {
+		result, _ := parseTestInput(rule, "(aa)", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(true))
+		AssertThat(c, len(result.Result)).Is(EqualTo(2))
+		AssertThat(c, result.Offset).Is(EqualTo[uint64](4))
+		result, _ = parseTestInput(rule, "(aa", batchSize)
+		AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected ')' near end of input at test:1:4; unclosed '(' opened at test:1:1"))
+		related := result.Error.RelatedPackets()
+		AssertThat(c, len(related)).Is(EqualTo(1))
+		AssertThat(c, related[0].Packet.Offset).Is(EqualTo[uint64](0))
+		result, _ = parseTestInput(rule, "(ab)", batchSize)
+		AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected ')' near 'b' at test:1:3; unclosed '(' opened at test:1:1"))
+		AssertThat(c, result.Offset).Is(EqualTo[uint64](2))
+		result, _ = parseTestInput(rule, "a)", batchSize)
+		AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected '(' near 'a' at test:1:1"))
+		AssertThat(c, len(result.Error.RelatedPackets())).Is(EqualTo(0))
+	})
+}
+
+func TestDelimitedBodyError(t *tst.T) {
+	c := Use(t)
+	pair := Sequence[testRune, int, *Packet[testRune], string](
+		The(0),
+		testCount[*Packet[testRune]],
+		testQuotedRuneToken('a'),
+		testQuotedRuneToken('b'),
+	)
+	rule := Delimited(nil, nil, testQuotedRuneToken('['), pair, testQuotedRuneToken(']'))
+	result, _ := parseTestInput(rule, "[a", 1)
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected 'b' near end of input at test:1:3; unclosed '[' at test:1:1"))
+	result, _ = parseTestInput(rule, "[ac]", 1)
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected 'b' near 'c' at test:1:3"))
+}
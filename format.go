@@ -0,0 +1,66 @@
+package gorecdesc
+
+type locatedItem interface {
+	locatedSymbol() any
+	locatedAt() Location
+}
+
+func(item Locatable[SymbolT]) locatedSymbol() any {
+	return item.Symbol
+}
+
+func(item Locatable[SymbolT]) locatedAt() Location {
+	return item.Location
+}
+
+func(item RangeLocatable[SymbolT]) locatedSymbol() any {
+	return item.Symbol
+}
+
+func(item RangeLocatable[SymbolT]) locatedAt() Location {
+	return item.Start
+}
+
+func describeLocatedSymbol(symbol any) string {
+	// located symbols are read from text, so integer symbols are characters there
+	switch value := symbol.(type) {
+		case rune:
+			return describeRune(value)
+		case byte:
+			return describeRune(rune(value))
+		default:
+			return describeToken(value)
+	}
+}
+
+func FormatPacket[ReadT any](packet *Packet[ReadT]) string {
+	if packet == nil {
+		return ""
+	}
+	located, isLocated := any(packet.Item).(locatedItem)
+	var rendition string
+	switch {
+		case packet.EOF:
+			rendition = "end of input"
+		case isLocated:
+			rendition = describeLocatedSymbol(located.locatedSymbol())
+		default:
+			rendition = describeToken(packet.Item)
+	}
+	if !isLocated {
+		return rendition
+	}
+	location := located.locatedAt()
+	if location.isEmpty() {
+		return rendition
+	}
+	return rendition + " at " + location.Format()
+}
+
+func FormatExpected[ExpectT any](expected ExpectT) string {
+	if description, isString := any(expected).(string); isString {
+		// expectations given as strings already describe themselves
+		return description
+	}
+	return describeToken(expected)
+}
@@ -0,0 +1,121 @@
+package gorecdesc
+
+import (
+	tst "testing"
+	. "github.com/UncleSniper/gotest"
+)
+
+type testStringerSymbol struct {
+	name string
+}
+
+func(symbol testStringerSymbol) String() string {
+	return "symbol " + symbol.name
+}
+
+func TestFormatPacketLocatableRune(t *tst.T) {
+	c := Use(t)
+	packet := &Packet[testRune] {
+		Item: testRune {
+			Symbol: 'a',
+			Location: StartOfFile("test"),
+		},
+	}
+	AssertThat(c, FormatPacket(packet)).Is(EqualTo("'a' at test:1:1"))
+}
+
+func TestFormatPacketLocatableByte(t *tst.T) {
+	c := Use(t)
+	packet := &Packet[Locatable[byte]] {
+		Item: Locatable[byte] {
+			Symbol: '\n',
+			Location: StartOfFile("test"),
+		},
+	}
+	AssertThat(c, FormatPacket(packet)).Is(EqualTo("'\\n' at test:1:1"))
+}
+
+func TestFormatPacketEOF(t *tst.T) {
+	c := Use(t)
+	packet := &Packet[testRune] {
+		Item: testRune {
+			Location: StartOfFile("test"),
+		},
+		EOF: true,
+	}
+	AssertThat(c, FormatPacket(packet)).Is(EqualTo("end of input at test:1:1"))
+	AssertThat(c, FormatPacket(&Packet[int] {EOF: true})).Is(EqualTo("end of input"))
+}
+
+func TestFormatPacketStringer(t *tst.T) {
+	c := Use(t)
+	packet := &Packet[testStringerSymbol] {
+		Item: testStringerSymbol {name: "foo"},
+	}
+	AssertThat(c, FormatPacket(packet)).Is(EqualTo("symbol foo"))
+	AssertThat(c, FormatPacket[int](nil)).Is(EqualTo(""))
+}
+
+func TestFormatExpected(t *tst.T) {
+	c := Use(t)
+	AssertThat(c, FormatExpected("digit")).Is(EqualTo("digit"))
+	AssertThat(c, FormatExpected(int32(65))).Is(EqualTo("65"))
+	AssertThat(c, FormatExpected(7)).Is(EqualTo("7"))
+	AssertThat(c, FormatExpected(testStringerSymbol {name: "bar"})).Is(EqualTo("symbol bar"))
+}
+
+func TestSyntaxErrorDefaultFormatExpected(t *tst.T) {
+	c := Use(t)
+	err := &SyntaxError[testRune, testStringerSymbol] {
+		Found: &Packet[testRune] {
+			Item: testRune {
+				Symbol: 'a',
+				Location: StartOfFile("test"),
+			},
+		},
+		FormatFound: FormatPacket[testRune],
+		Expected: []testStringerSymbol {{name: "x"}, {name: "y"}},
+	}
+	AssertThat(c, err.Error()).Is(EqualTo("Expected symbol x, or symbol y near 'a' at test:1:1"))
+}
+
+func TestSingleTokenDefaultFormatters(t *tst.T) {
+	c := Use(t)
+	rule := SingleToken[testRune, string](FormatPacket[testRune], "digit", nil, TokenPredicate(func(item testRune) bool {
+		return item.Symbol >= '0' && item.Symbol <= '9'
+	}))
+	result, _ := parseTestInput(rule, "a", 1)
+	AssertThat(c, result.Error == nil).Is(EqualTo(false))
+	AssertThat(c, result.Error.Error()).Is(EqualTo("Expected digit near 'a' at test:1:1"))
+}
+
+func TestErrorsDefaultFormatFound(t *tst.T) {
+	c := Use(t)
+	found := &Packet[testRune] {
+		Item: testRune {
+			Symbol: 'a',
+			Location: StartOfFile("test"),
+		},
+	}
+	syntaxErr := &SyntaxError[testRune, string] {
+		Found: found,
+		Expected: []string {"digit"},
+	}
+	AssertThat(c, syntaxErr.Error()).Is(EqualTo("Expected digit near 'a' at test:1:1"))
+	repetitionErr := &InfiniteRepetitionError[testRune, string] {
+		Found: found,
+	}
+	AssertThat(c, repetitionErr.Error()).Is(EqualTo(
+		"Repetition near 'a' at test:1:1 would be infinite: Iteration consumed no packets but did not fail, either",
+	))
+	ambiguityErr := &AmbiguityError[testRune, string] {
+		StartsAt: found,
+	}
+	AssertThat(c, ambiguityErr.Error()).Is(EqualTo("Ambiguity in grammar starting at 'a' at test:1:1"))
+}
+
+func TestFormatPacketPlainIntegers(t *tst.T) {
+	c := Use(t)
+	AssertThat(c, FormatPacket(&Packet[int32] {Item: 65})).Is(EqualTo("65"))
+	AssertThat(c, FormatPacket(&Packet[byte] {Item: 10})).Is(EqualTo("10"))
+}
@@ -0,0 +1,487 @@
+package gorecdesc
+
+import (
+	"reflect"
+)
+
+type Grammar[ReadT any, ExpectT any] struct {
+	FormatPacket func(*Packet[ReadT]) string
+	CompareExpect func(ExpectT, ExpectT) bool
+	FormatExpected func(ExpectT) string
+	NoChoice *ExpectT
+	Trivia Rule[ReadT, []TriviaPiece, ExpectT]
+}
+
+func NewGrammar[ReadT any, ExpectT any]() *Grammar[ReadT, ExpectT] {
+	return &Grammar[ReadT, ExpectT] {
+		FormatPacket: FormatPacket[ReadT],
+		CompareExpect: equalExpectations[ExpectT](),
+	}
+}
+
+func equalExpectations[ExpectT any]() func(ExpectT, ExpectT) bool {
+	expectType := reflect.TypeOf((*ExpectT)(nil)).Elem()
+	if !expectType.Comparable() {
+		return nil
+	}
+	return func(left ExpectT, right ExpectT) (equal bool) {
+		// interfaces (also inside structs) may hold values that cannot be compared after all
+		defer func() {
+			if recover() != nil {
+				equal = false
+			}
+		}()
+		return any(left) == any(right)
+	}
+}
+
+func(grammar *Grammar[ReadT, ExpectT]) WithFormatPacket(
+	formatPacket func(*Packet[ReadT]) string,
+) *Grammar[ReadT, ExpectT] {
+	grammar.FormatPacket = formatPacket
+	return grammar
+}
+
+func(grammar *Grammar[ReadT, ExpectT]) WithCompareExpect(
+	compareExpect func(ExpectT, ExpectT) bool,
+) *Grammar[ReadT, ExpectT] {
+	grammar.CompareExpect = compareExpect
+	return grammar
+}
+
+func(grammar *Grammar[ReadT, ExpectT]) WithFormatExpected(
+	formatExpected func(ExpectT) string,
+) *Grammar[ReadT, ExpectT] {
+	grammar.FormatExpected = formatExpected
+	return grammar
+}
+
+func(grammar *Grammar[ReadT, ExpectT]) WithNoChoice(noChoice ExpectT) *Grammar[ReadT, ExpectT] {
+	grammar.NoChoice = &noChoice
+	return grammar
+}
+
+func(grammar *Grammar[ReadT, ExpectT]) formatExpected() func(ExpectT) string {
+	// left nil, FormatExpected lets each rule describe itself; where we hand over
+	// an expectation of our own, it has to be formatted somehow
+	if grammar.FormatExpected == nil {
+		return FormatExpected[ExpectT]
+	}
+	return grammar.FormatExpected
+}
+
+func(grammar *Grammar[ReadT, ExpectT]) WithTrivia(
+	trivia Rule[ReadT, []TriviaPiece, ExpectT],
+) *Grammar[ReadT, ExpectT] {
+	// tokens skip the trivia after them; trivia before the first token is only
+	// skipped by the entry rule, i.e. by Complete or SkipTrivia
+	grammar.Trivia = trivia
+	return grammar
+}
+
+func grammarToken[ReadT any, OutT any, ExpectT any](
+	grammar *Grammar[ReadT, ExpectT],
+	rule Rule[ReadT, OutT, ExpectT],
+) Rule[ReadT, OutT, ExpectT] {
+	if grammar.Trivia == nil {
+		return rule
+	}
+	return Lexeme(grammar.Trivia, rule)
+}
+
+func grammarTokenWithTrivia[ReadT any, OutT any, ExpectT any](
+	grammar *Grammar[ReadT, ExpectT],
+	rule Rule[ReadT, OutT, ExpectT],
+) Rule[ReadT, Lexed[OutT, []TriviaPiece], ExpectT] {
+	return LexemeWithTrivia(grammar.Trivia, rule)
+}
+
+func(grammar *Grammar[ReadT, ExpectT]) SingleToken(
+	expected ExpectT,
+	predicate func(*Packet[ReadT]) bool,
+) Rule[ReadT, *Packet[ReadT], ExpectT] {
+	return grammarToken(grammar, SingleToken(grammar.FormatPacket, expected, grammar.FormatExpected, predicate))
+}
+
+func(grammar *Grammar[ReadT, ExpectT]) SingleTokenWithTrivia(
+	expected ExpectT,
+	predicate func(*Packet[ReadT]) bool,
+) Rule[ReadT, Lexed[*Packet[ReadT], []TriviaPiece], ExpectT] {
+	return grammarTokenWithTrivia(
+		grammar,
+		SingleToken(grammar.FormatPacket, expected, grammar.FormatExpected, predicate),
+	)
+}
+
+func itemPredicate[ReadT any](predicate func(ReadT) bool) func(*Packet[ReadT]) bool {
+	return func(packet *Packet[ReadT]) bool {
+		return !packet.EOF && predicate(packet.Item)
+	}
+}
+
+func(grammar *Grammar[ReadT, ExpectT]) Token(
+	expected ExpectT,
+	predicate func(ReadT) bool,
+) Rule[ReadT, *Packet[ReadT], ExpectT] {
+	return grammar.SingleToken(expected, itemPredicate(predicate))
+}
+
+func(grammar *Grammar[ReadT, ExpectT]) TokenWithTrivia(
+	expected ExpectT,
+	predicate func(ReadT) bool,
+) Rule[ReadT, Lexed[*Packet[ReadT], []TriviaPiece], ExpectT] {
+	return grammar.SingleTokenWithTrivia(expected, itemPredicate(predicate))
+}
+
+func(grammar *Grammar[ReadT, ExpectT]) MatchToken(
+	class TokenClass[ReadT],
+	expected ExpectT,
+) Rule[ReadT, *Packet[ReadT], ExpectT] {
+	return grammarToken(grammar, MatchToken(grammar.FormatPacket, class, expected, grammar.FormatExpected))
+}
+
+func(grammar *Grammar[ReadT, ExpectT]) MatchTokenWithTrivia(
+	class TokenClass[ReadT],
+	expected ExpectT,
+) Rule[ReadT, Lexed[*Packet[ReadT], []TriviaPiece], ExpectT] {
+	return grammarTokenWithTrivia(
+		grammar,
+		MatchToken(grammar.FormatPacket, class, expected, grammar.FormatExpected),
+	)
+}
+
+func(grammar *Grammar[ReadT, ExpectT]) Guard(
+	expected ExpectT,
+	predicate func(*Reader[ReadT]) bool,
+) Rule[ReadT, *Packet[ReadT], ExpectT] {
+	return Guard(grammar.FormatPacket, expected, grammar.FormatExpected, predicate)
+}
+
+func(grammar *Grammar[ReadT, ExpectT]) EndOfInput(expected ExpectT) Rule[ReadT, *Packet[ReadT], ExpectT] {
+	return EndOfInput(grammar.FormatPacket, expected, grammar.FormatExpected)
+}
+
+type Productions[OutT any, ReadT any, ExpectT any] struct {
+	Grammar *Grammar[ReadT, ExpectT]
+}
+
+func Of[OutT any, ReadT any, ExpectT any](grammar *Grammar[ReadT, ExpectT]) Productions[OutT, ReadT, ExpectT] {
+	return Productions[OutT, ReadT, ExpectT] {
+		Grammar: grammar,
+	}
+}
+
+func(productions Productions[OutT, ReadT, ExpectT]) Choice(
+	structure string,
+	choices ...Rule[ReadT, OutT, ExpectT],
+) Rule[ReadT, OutT, ExpectT] {
+	grammar := productions.Grammar
+	var noChoice ExpectT
+	var formatNoChoice func(ExpectT) string
+	if grammar.NoChoice != nil {
+		noChoice = *grammar.NoChoice
+		formatNoChoice = grammar.formatExpected()
+	}
+	return Choice(
+		structure,
+		grammar.FormatPacket,
+		noChoice,
+		formatNoChoice,
+		grammar.CompareExpect,
+		grammar.FormatExpected,
+		choices...,
+	)
+}
+
+func(productions Productions[OutT, ReadT, ExpectT]) Named(
+	structure string,
+	rule Rule[ReadT, OutT, ExpectT],
+) Rule[ReadT, OutT, ExpectT] {
+	return Named(structure, rule)
+}
+
+func(productions Productions[OutT, ReadT, ExpectT]) Option(
+	none OutT,
+	subjectRule Rule[ReadT, OutT, ExpectT],
+) Rule[ReadT, OutT, ExpectT] {
+	return Option(productions.Grammar.FormatPacket, none, subjectRule)
+}
+
+func(productions Productions[OutT, ReadT, ExpectT]) Many(
+	itemRule Rule[ReadT, OutT, ExpectT],
+) Rule[ReadT, []OutT, ExpectT] {
+	return Many(productions.Grammar.FormatPacket, itemRule)
+}
+
+func(productions Productions[OutT, ReadT, ExpectT]) Many1(
+	itemRule Rule[ReadT, OutT, ExpectT],
+) Rule[ReadT, []OutT, ExpectT] {
+	return Many1(productions.Grammar.FormatPacket, itemRule)
+}
+
+func(productions Productions[OutT, ReadT, ExpectT]) SepBy(
+	itemRule Rule[ReadT, OutT, ExpectT],
+	separatorRule Rule[ReadT, *Packet[ReadT], ExpectT],
+) Rule[ReadT, []OutT, ExpectT] {
+	return SepBy(productions.Grammar.FormatPacket, itemRule, separatorRule)
+}
+
+func(productions Productions[OutT, ReadT, ExpectT]) SepBy1(
+	itemRule Rule[ReadT, OutT, ExpectT],
+	separatorRule Rule[ReadT, *Packet[ReadT], ExpectT],
+) Rule[ReadT, []OutT, ExpectT] {
+	return SepBy1(productions.Grammar.FormatPacket, itemRule, separatorRule)
+}
+
+func(productions Productions[OutT, ReadT, ExpectT]) SepEndBy(
+	itemRule Rule[ReadT, OutT, ExpectT],
+	separatorRule Rule[ReadT, *Packet[ReadT], ExpectT],
+) Rule[ReadT, []OutT, ExpectT] {
+	return SepEndBy(productions.Grammar.FormatPacket, itemRule, separatorRule)
+}
+
+func(productions Productions[OutT, ReadT, ExpectT]) Count(
+	count uint64,
+	itemRule Rule[ReadT, OutT, ExpectT],
+) Rule[ReadT, []OutT, ExpectT] {
+	return Count(productions.Grammar.FormatPacket, count, itemRule)
+}
+
+func(productions Productions[OutT, ReadT, ExpectT]) Map(
+	unmapped ExpectT,
+	innerRule Rule[ReadT, *Packet[ReadT], ExpectT],
+	mapping func(*Packet[ReadT]) OutT,
+) Rule[ReadT, OutT, ExpectT] {
+	grammar := productions.Grammar
+	return MapRule(grammar.FormatPacket, unmapped, grammar.FormatExpected, innerRule, mapping)
+}
+
+func(productions Productions[OutT, ReadT, ExpectT]) Delimited(
+	describeOpen func(*Packet[ReadT]) string,
+	openRule Rule[ReadT, *Packet[ReadT], ExpectT],
+	bodyRule Rule[ReadT, OutT, ExpectT],
+	closeRule Rule[ReadT, *Packet[ReadT], ExpectT],
+) Rule[ReadT, OutT, ExpectT] {
+	return Delimited(productions.Grammar.FormatPacket, describeOpen, openRule, bodyRule, closeRule)
+}
+
+func(productions Productions[OutT, ReadT, ExpectT]) Complete(
+	expected ExpectT,
+	rule Rule[ReadT, OutT, ExpectT],
+) Rule[ReadT, OutT, ExpectT] {
+	grammar := productions.Grammar
+	// tokens skip the trivia after them, so only the leading trivia is left to us
+	return Complete(grammar.FormatPacket, expected, grammar.FormatExpected, productions.SkipTrivia(rule))
+}
+
+func(productions Productions[OutT, ReadT, ExpectT]) Permutation(
+	structure string,
+	separatorRule Rule[ReadT, *Packet[ReadT], ExpectT],
+	entries ...PermutationEntry[ReadT, OutT, ExpectT],
+) Rule[ReadT, []PermutationSlot[OutT], ExpectT] {
+	grammar := productions.Grammar
+	return Permutation(
+		structure,
+		grammar.FormatPacket,
+		grammar.CompareExpect,
+		grammar.FormatExpected,
+		separatorRule,
+		entries...,
+	)
+}
+
+func(productions Productions[OutT, ReadT, ExpectT]) Lexeme(
+	rule Rule[ReadT, OutT, ExpectT],
+) Rule[ReadT, OutT, ExpectT] {
+	return grammarToken(productions.Grammar, rule)
+}
+
+func(productions Productions[OutT, ReadT, ExpectT]) SkipTrivia(
+	rule Rule[ReadT, OutT, ExpectT],
+) Rule[ReadT, OutT, ExpectT] {
+	grammar := productions.Grammar
+	if grammar.Trivia == nil {
+		return rule
+	}
+	return SkipTrivia(grammar.Trivia, rule)
+}
+
+func(productions Productions[OutT, ReadT, ExpectT]) LexemeWithTrivia(
+	rule Rule[ReadT, OutT, ExpectT],
+) Rule[ReadT, Lexed[OutT, []TriviaPiece], ExpectT] {
+	return LexemeWithTrivia(productions.Grammar.Trivia, rule)
+}
+
+type Accumulations[AccumulatorT any, PieceT any, ReadT any, ExpectT any] struct {
+	Grammar *Grammar[ReadT, ExpectT]
+}
+
+func Into[AccumulatorT any, PieceT any, ReadT any, ExpectT any](
+	grammar *Grammar[ReadT, ExpectT],
+) Accumulations[AccumulatorT, PieceT, ReadT, ExpectT] {
+	return Accumulations[AccumulatorT, PieceT, ReadT, ExpectT] {
+		Grammar: grammar,
+	}
+}
+
+func(accumulations Accumulations[AccumulatorT, PieceT, ReadT, ExpectT]) Sequence(
+	initAccu InitAccu[AccumulatorT],
+	combineAccu CombineAccu[AccumulatorT, PieceT],
+	children ...Rule[ReadT, PieceT, ExpectT],
+) Rule[ReadT, AccumulatorT, ExpectT] {
+	return accumulations.TrySequence(initAccu, TryAccu(combineAccu), children...)
+}
+
+func(accumulations Accumulations[AccumulatorT, PieceT, ReadT, ExpectT]) TrySequence(
+	initAccu InitAccu[AccumulatorT],
+	combineAccu TryCombineAccu[AccumulatorT, PieceT],
+	children ...Rule[ReadT, PieceT, ExpectT],
+) Rule[ReadT, AccumulatorT, ExpectT] {
+	return TrySequence(accumulations.Grammar.FormatPacket, initAccu, combineAccu, children...)
+}
+
+func(accumulations Accumulations[AccumulatorT, PieceT, ReadT, ExpectT]) Repetition(
+	initAccu InitAccu[AccumulatorT],
+	combineAccu CombineBiAccu[AccumulatorT, *Packet[ReadT], PieceT],
+	noItem ExpectT,
+	itemRule Rule[ReadT, PieceT, ExpectT],
+	separatorRule Rule[ReadT, *Packet[ReadT], ExpectT],
+	minItems uint64,
+	maxItems uint64,
+	allowTrailingSeparator bool,
+) Rule[ReadT, AccumulatorT, ExpectT] {
+	return accumulations.TryRepetition(
+		initAccu,
+		TryBiAccu(combineAccu),
+		noItem,
+		itemRule,
+		separatorRule,
+		minItems,
+		maxItems,
+		allowTrailingSeparator,
+	)
+}
+
+func(accumulations Accumulations[AccumulatorT, PieceT, ReadT, ExpectT]) TryRepetition(
+	initAccu InitAccu[AccumulatorT],
+	combineAccu TryCombineBiAccu[AccumulatorT, *Packet[ReadT], PieceT],
+	noItem ExpectT,
+	itemRule Rule[ReadT, PieceT, ExpectT],
+	separatorRule Rule[ReadT, *Packet[ReadT], ExpectT],
+	minItems uint64,
+	maxItems uint64,
+	allowTrailingSeparator bool,
+) Rule[ReadT, AccumulatorT, ExpectT] {
+	grammar := accumulations.Grammar
+	return TryRepetition(
+		grammar.FormatPacket,
+		initAccu,
+		combineAccu,
+		noItem,
+		grammar.formatExpected(),
+		itemRule,
+		separatorRule,
+		minItems,
+		maxItems,
+		allowTrailingSeparator,
+	)
+}
+
+func(accumulations Accumulations[AccumulatorT, PieceT, ReadT, ExpectT]) RepetitionWithTrailing(
+	initAccu InitAccu[AccumulatorT],
+	combineAccu CombineBiAccu[AccumulatorT, *Packet[ReadT], PieceT],
+	combineTrailing CombineAccu[AccumulatorT, *Packet[ReadT]],
+	noItem ExpectT,
+	itemRule Rule[ReadT, PieceT, ExpectT],
+	separatorRule Rule[ReadT, *Packet[ReadT], ExpectT],
+	minItems uint64,
+	maxItems uint64,
+) Rule[ReadT, AccumulatorT, ExpectT] {
+	grammar := accumulations.Grammar
+	return RepetitionWithTrailing(
+		grammar.FormatPacket,
+		initAccu,
+		combineAccu,
+		combineTrailing,
+		noItem,
+		grammar.formatExpected(),
+		itemRule,
+		separatorRule,
+		minItems,
+		maxItems,
+	)
+}
+
+type Text[ExpectT any] struct {
+	Grammar *Grammar[Locatable[rune], ExpectT]
+}
+
+func TextOf[ExpectT any](grammar *Grammar[Locatable[rune], ExpectT]) Text[ExpectT] {
+	return Text[ExpectT] {
+		Grammar: grammar,
+	}
+}
+
+func(text Text[ExpectT]) Literal(
+	expected ExpectT,
+	literal string,
+) Rule[Locatable[rune], RangeLocatable[string], ExpectT] {
+	grammar := text.Grammar
+	return grammarToken(grammar, Literal(grammar.FormatPacket, expected, grammar.FormatExpected, literal))
+}
+
+func(text Text[ExpectT]) LiteralWithTrivia(
+	expected ExpectT,
+	literal string,
+) Rule[Locatable[rune], Lexed[RangeLocatable[string], []TriviaPiece], ExpectT] {
+	grammar := text.Grammar
+	return grammarTokenWithTrivia(grammar, Literal(grammar.FormatPacket, expected, grammar.FormatExpected, literal))
+}
+
+func(text Text[ExpectT]) Keyword(
+	expected ExpectT,
+	keyword string,
+) Rule[Locatable[rune], RangeLocatable[string], ExpectT] {
+	grammar := text.Grammar
+	return grammarToken(grammar, Keyword(grammar.FormatPacket, expected, grammar.FormatExpected, keyword))
+}
+
+func(text Text[ExpectT]) KeywordWithTrivia(
+	expected ExpectT,
+	keyword string,
+) Rule[Locatable[rune], Lexed[RangeLocatable[string], []TriviaPiece], ExpectT] {
+	grammar := text.Grammar
+	return grammarTokenWithTrivia(grammar, Keyword(grammar.FormatPacket, expected, grammar.FormatExpected, keyword))
+}
+
+func(text Text[ExpectT]) Regex(
+	expected ExpectT,
+	pattern string,
+) (Rule[Locatable[rune], RangeLocatable[string], ExpectT], error) {
+	grammar := text.Grammar
+	rule, err := Regex(grammar.FormatPacket, expected, grammar.FormatExpected, pattern)
+	if err != nil {
+		return nil, err
+	}
+	return grammarToken(grammar, rule), nil
+}
+
+func(text Text[ExpectT]) RegexWithTrivia(
+	expected ExpectT,
+	pattern string,
+) (Rule[Locatable[rune], Lexed[RangeLocatable[string], []TriviaPiece], ExpectT], error) {
+	grammar := text.Grammar
+	rule, err := Regex(grammar.FormatPacket, expected, grammar.FormatExpected, pattern)
+	if err != nil {
+		return nil, err
+	}
+	return grammarTokenWithTrivia(grammar, rule), nil
+}
+
+func(text Text[ExpectT]) Trivia(
+	expected ExpectT,
+	syntax TriviaSyntax,
+) Rule[Locatable[rune], []TriviaPiece, ExpectT] {
+	grammar := text.Grammar
+	return Trivia(grammar.FormatPacket, expected, grammar.FormatExpected, syntax)
+}
@@ -0,0 +1,217 @@
+package gorecdesc
+
+import (
+	tst "testing"
+	. "github.com/UncleSniper/gotest"
+)
+
+func testGrammarDigits() Rule[testRune, []int, string] {
+	grammar := NewGrammar[testRune, string]()
+	digit := Of[int](grammar).Map(
+		"digit",
+		grammar.MatchToken(Located(DigitRune()), "digit"),
+		func(packet *Packet[testRune]) int {
+			if packet == nil {
+				return 0
+			}
+			return int(packet.Item.Symbol - '0')
+		},
+	)
+	comma := grammar.Token("','", func(item testRune) bool {
+		return item.Symbol == ','
+	})
+	return Of[[]int](grammar).Complete("end of input", Of[int](grammar).SepBy1(digit, comma))
+}
+
+func TestGrammar(t *tst.T) {
+	c := Use(t)
+	rule := testGrammarDigits()
+	result, _ := parseTestInput(rule, "1,2,3", 1)
+	AssertThat(c, result.Error == nil).Is(EqualTo(true))
+	AssertThat(c, len(result.Result)).Is(EqualTo(3))
+	AssertThat(c, result.Result[2]).Is(EqualTo(3))
+	result, _ = parseTestInput(rule, "1,x", 1)
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected digit near 'x' at test:1:3"))
+	result, _ = parseTestInput(rule, "1;", 1)
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected end of input near ';' at test:1:2"))
+}
+
+func TestGrammarChoice(t *tst.T) {
+	c := Use(t)
+	grammar := NewGrammar[testRune, string]().WithCompareExpect(func(a string, b string) bool {
+		return a == b
+	})
+	letter := grammar.MatchToken(Located(LetterRune()), "letter")
+	digit := grammar.MatchToken(Located(DigitRune()), "digit")
+	rule := Of[*Packet[testRune]](grammar).Choice("atom", letter, digit, letter)
+	result, _ := parseTestInput(rule, "7", 1)
+	AssertThat(c, result.Error == nil).Is(EqualTo(true))
+	result, _ = parseTestInput(rule, "+", 1)
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected letter, or digit near '+' at test:1:1 for atom"))
+}
+
+func TestGrammarMergesEqualExpectationsByDefault(t *tst.T) {
+	c := Use(t)
+	grammar := NewGrammar[testRune, string]()
+	letter := grammar.MatchToken(Located(LetterRune()), "letter")
+	digit := grammar.MatchToken(Located(DigitRune()), "digit")
+	rule := Of[*Packet[testRune]](grammar).Choice("atom", letter, digit, letter)
+	result, _ := parseTestInput(rule, "+", 1)
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected letter, or digit near '+' at test:1:1 for atom"))
+	AssertThat(c, NewGrammar[testRune, []string]().CompareExpect == nil).Is(EqualTo(true))
+	compareAny := NewGrammar[testRune, any]().CompareExpect
+	AssertThat(c, compareAny(1, 1)).Is(EqualTo(true))
+	AssertThat(c, compareAny([]int {1}, []int {1})).Is(EqualTo(false))
+}
+
+func TestGrammarNoChoice(t *tst.T) {
+	c := Use(t)
+	grammar := NewGrammar[testRune, string]()
+	rule := Of[*Packet[testRune]](grammar).Choice("atom")
+	result, _ := parseTestInput(rule, "a", 1)
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected one of zero choices near 'a' at test:1:1 for atom"))
+	grammar.WithNoChoice("an atom")
+	rule = Of[*Packet[testRune]](grammar).Choice("atom")
+	result, _ = parseTestInput(rule, "a", 1)
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected an atom near 'a' at test:1:1 for atom"))
+	// the formatter is looked up when the rule is built, not when the expectation is set
+	grammar.WithFormatExpected(func(expected string) string {
+		return "<" + expected + ">"
+	})
+	rule = Of[*Packet[testRune]](grammar).Choice("atom")
+	result, _ = parseTestInput(rule, "a", 1)
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected <an atom> near 'a' at test:1:1 for atom"))
+}
+
+func TestGrammarAccumulations(t *tst.T) {
+	c := Use(t)
+	grammar := NewGrammar[testRune, string]()
+	letter := grammar.MatchToken(Located(LetterRune()), "letter")
+	comma := grammar.MatchToken(Located(RuneEquals(',')), "','")
+	pair := Into[int, *Packet[testRune]](grammar).Sequence(The(0), testCount[*Packet[testRune]], letter, letter)
+	result, _ := parseTestInput(Of[int](grammar).Named("pair", pair), "ab", 1)
+	AssertThat(c, result.Error == nil).Is(EqualTo(true))
+	AssertThat(c, result.Result).Is(EqualTo(2))
+	result, _ = parseTestInput(Of[int](grammar).Named("pair", pair), "a1", 1)
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected letter near '1' at test:1:2 to continue pair"))
+	letters := Into[string, *Packet[testRune]](grammar).RepetitionWithTrailing(
+		nil,
+		func(accumulator string, separator *Packet[testRune], item *Packet[testRune]) string {
+			return accumulator + string(item.Item.Symbol)
+		},
+		nil,
+		"letter",
+		letter,
+		comma,
+		1,
+		3,
+	)
+	forEachTestBatchSize(func(batchSize uint) {
+		result, _ := parseTestInput(letters, "a,b,", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(true))
+		AssertThat(c, result.Result).Is(EqualTo("ab"))
+	})
+	result2, _ := parseTestInput(letters, "1", 1)
+	AssertThat[error](c, result2.Error).Is(ErrorWithMessage("Expected letter near '1' at test:1:1"))
+}
+
+func TestGrammarText(t *tst.T) {
+	c := Use(t)
+	grammar := NewGrammar[testRune, string]()
+	text := TextOf(grammar)
+	number, err := text.Regex("number", "[0-9]+")
+	AssertThat(c, err).Is(ZeroValue[error]())
+	rule := Into[int, RangeLocatable[string]](grammar).Sequence(
+		The(0),
+		testCount[RangeLocatable[string]],
+		text.Keyword("let keyword", "let"),
+		text.Literal("assignment", "="),
+		number,
+	)
+	result, _ := parseTestInput(rule, "let=42", 1)
+	AssertThat(c, result.Error == nil).Is(EqualTo(true))
+	AssertThat(c, result.Result).Is(EqualTo(3))
+	// without a formatter of its own, the grammar keeps the rules' own descriptions
+	result, _ = parseTestInput(rule, "letter=42", 1)
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected \"let\" near 'l' at test:1:1"))
+	result, _ = parseTestInput(rule, "let:42", 1)
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected \"=\" near ':' at test:1:4"))
+	result, _ = parseTestInput(rule, "let=x", 1)
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected text matching /[0-9]+/ near 'x' at test:1:5"))
+	trivia := text.Trivia("comment end", testTriviaSyntax)
+	comment, _ := parseTestInput(trivia, "/* x", 1)
+	AssertThat[error](c, comment.Error).Is(ErrorWithMessage("Expected end of block comment near '/' at test:1:1"))
+	digit := grammar.MatchToken(Located(DigitRune()), "number")
+	packet, _ := parseTestInput(digit, "x", 1)
+	AssertThat[error](c, packet.Error).Is(ErrorWithMessage("Expected digit near 'x' at test:1:1"))
+	// an explicit formatter describes the expectations given to the grammar instead
+	grammar.WithFormatExpected(func(expected string) string {
+		return expected
+	})
+	keyword := text.Keyword("let keyword", "let")
+	word, _ := parseTestInput(keyword, "letter", 1)
+	AssertThat[error](c, word.Error).Is(ErrorWithMessage("Expected let keyword near 'l' at test:1:1"))
+	digit = grammar.MatchToken(Located(DigitRune()), "number")
+	packet, _ = parseTestInput(digit, "x", 1)
+	AssertThat[error](c, packet.Error).Is(ErrorWithMessage("Expected number near 'x' at test:1:1"))
+}
+
+func TestGrammarTrivia(t *tst.T) {
+	c := Use(t)
+	grammar := NewGrammar[testRune, string]()
+	text := TextOf(grammar)
+	grammar.WithTrivia(text.Trivia("end of block comment", testTriviaSyntax))
+	number, _ := text.Regex("number", "[0-9]+")
+	digit := grammar.MatchToken(Located(DigitRune()), "digit")
+	rule := Of[int](grammar).Complete("end of input", Into[int, RangeLocatable[string]](grammar).Sequence(
+		The(0),
+		testCount[RangeLocatable[string]],
+		text.Keyword("'let'", "let"),
+		text.Literal("'='", "="),
+		number,
+	))
+	forEachTestBatchSize(func(batchSize uint) {
+		result, _ := parseTestInput(rule, " /* a /* b */ */ let = // x\n 42 ", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(true))
+		AssertThat(c, result.Result).Is(EqualTo(3))
+		packets, _ := parseTestInput(Of[[]*Packet[testRune]](grammar).Complete(
+			"end of input",
+			Of[*Packet[testRune]](grammar).Many(digit),
+		), "1 2\t3 ", batchSize)
+		AssertThat(c, packets.Error == nil).Is(EqualTo(true))
+		AssertThat(c, len(packets.Result)).Is(EqualTo(3))
+	})
+	result, _ := parseTestInput(rule, "let = /* x", 1)
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected end of block comment near '/' at test:1:7"))
+	// grammar tokens that keep their trivia, e.g. for formatters preserving comments
+	kept := text.LiteralWithTrivia("x", "x")
+	lexed, _ := parseTestInput(kept, "x // note", 1)
+	AssertThat(c, lexed.Error == nil).Is(EqualTo(true))
+	AssertThat(c, lexed.Result.Value.Symbol).Is(EqualTo("x"))
+	AssertThat(c, len(lexed.Result.Trivia)).Is(EqualTo(2))
+	AssertThat(c, lexed.Result.Trivia[1].Text).Is(EqualTo("// note"))
+	keptDigits := Into[[]string, Lexed[*Packet[testRune], []TriviaPiece]](grammar).Sequence(
+		nil,
+		func(comments []string, digit Lexed[*Packet[testRune], []TriviaPiece]) []string {
+			for _, piece := range digit.Trivia {
+				if piece.Kind != TRIVIA_WHITESPACE {
+					comments = append(comments, piece.Text)
+				}
+			}
+			return comments
+		},
+		grammar.MatchTokenWithTrivia(Located(DigitRune()), "digit"),
+		grammar.MatchTokenWithTrivia(Located(DigitRune()), "digit"),
+	)
+	comments, _ := parseTestInput(keptDigits, "1 /* a */ 2 // b", 1)
+	AssertThat(c, comments.Error == nil).Is(EqualTo(true))
+	AssertThat(c, len(comments.Result)).Is(EqualTo(2))
+	AssertThat(c, comments.Result[0]).Is(EqualTo("/* a */"))
+	AssertThat(c, comments.Result[1]).Is(EqualTo("// b"))
+	// without Complete, the entry rule has to skip leading trivia itself
+	leading, _ := parseTestInput(digit, " 1", 1)
+	AssertThat(c, leading.Error == nil).Is(EqualTo(false))
+	leading, _ = parseTestInput(Of[*Packet[testRune]](grammar).SkipTrivia(digit), " /* a */ 1 ", 1)
+	AssertThat(c, leading.Error == nil).Is(EqualTo(true))
+	AssertThat(c, leading.Offset).Is(EqualTo[uint64](11))
+}
@@ -0,0 +1,325 @@
+package gorecdesc
+
+import (
+	"errors"
+	"io"
+	"strconv"
+)
+
+type LayoutKind uint
+
+const (
+	LAYOUT_RUNE LayoutKind = iota
+	LAYOUT_NEWLINE
+	LAYOUT_INDENT
+	LAYOUT_DEDENT
+	LAYOUT_BAD_DEDENT
+)
+
+type LayoutSymbol struct {
+	Kind LayoutKind
+	Rune rune
+}
+
+type layoutState struct {
+	disp *Dispatcher[Locatable[LayoutSymbol]]
+	tabWidth uint
+	levels []uint
+	pending []Locatable[rune]
+	atLineStart bool
+	lineHasContent bool
+	carriage *Locatable[rune]
+}
+
+func(state *layoutState) emit(kind LayoutKind, r rune, location Location) {
+	state.disp.Send(Locatable[LayoutSymbol] {
+		Symbol: LayoutSymbol {
+			Kind: kind,
+			Rune: r,
+		},
+		Location: location,
+	}, false)
+}
+
+func(state *layoutState) indentation() uint {
+	var width uint
+	for _, item := range state.pending {
+		if item.Symbol == '\t' && state.tabWidth > 0 {
+			width += state.tabWidth - width % state.tabWidth
+		} else {
+			width++
+		}
+	}
+	return width
+}
+
+func(state *layoutState) startLine(location Location) {
+	width := state.indentation()
+	state.pending = nil
+	state.atLineStart = false
+	state.lineHasContent = true
+	top := state.levels[len(state.levels) - 1]
+	if width > top {
+		state.levels = append(state.levels, width)
+		state.emit(LAYOUT_INDENT, 0, location)
+		return
+	}
+	dedents := 0
+	for width < state.levels[len(state.levels) - 1] {
+		state.levels = state.levels[:len(state.levels) - 1]
+		dedents++
+	}
+	if width > state.levels[len(state.levels) - 1] {
+		// dedented to a level that was never opened, so the innermost dedent is bad
+		if debugOn {
+			debugf("[SendLayout] Bad dedent to width %d at %s\n", width, location.Format())
+		}
+		state.levels = append(state.levels, width)
+		dedents--
+		for ; dedents > 0; dedents-- {
+			state.emit(LAYOUT_DEDENT, 0, location)
+		}
+		state.emit(LAYOUT_BAD_DEDENT, 0, location)
+		return
+	}
+	for ; dedents > 0; dedents-- {
+		state.emit(LAYOUT_DEDENT, 0, location)
+	}
+}
+
+func(state *layoutState) feed(r rune, location Location) {
+	if state.carriage != nil {
+		carriage := state.carriage
+		state.carriage = nil
+		if r == '\n' {
+			// CRLF ends the line just like a lone LF does
+			state.endLine(carriage.Location)
+			return
+		}
+		state.emit(LAYOUT_RUNE, carriage.Symbol, carriage.Location)
+	}
+	if state.atLineStart {
+		switch r {
+			case ' ', '\t', '\r', '\f':
+				state.pending = append(state.pending, Locatable[rune] {
+					Symbol: r,
+					Location: location,
+				})
+				return
+			case '\n':
+				// blank lines do not take part in the layout
+				state.pending = nil
+				return
+		}
+		state.startLine(location)
+	}
+	switch r {
+		case '\n':
+			state.endLine(location)
+		case '\r':
+			state.carriage = &Locatable[rune] {
+				Symbol: r,
+				Location: location,
+			}
+		default:
+			state.emit(LAYOUT_RUNE, r, location)
+	}
+}
+
+func(state *layoutState) endLine(location Location) {
+	state.emit(LAYOUT_NEWLINE, '\n', location)
+	state.atLineStart = true
+	state.lineHasContent = false
+}
+
+func(state *layoutState) finish(location Location) {
+	if state.carriage != nil {
+		state.emit(LAYOUT_RUNE, state.carriage.Symbol, state.carriage.Location)
+		state.carriage = nil
+	}
+	if state.lineHasContent {
+		state.emit(LAYOUT_NEWLINE, 0, location)
+	}
+	for len(state.levels) > 1 {
+		state.levels = state.levels[:len(state.levels) - 1]
+		state.emit(LAYOUT_DEDENT, 0, location)
+	}
+	state.disp.Send(Locatable[LayoutSymbol] {
+		Location: location,
+	}, true)
+}
+
+func SendLayout(
+	disp *Dispatcher[Locatable[LayoutSymbol]],
+	reader io.RuneReader,
+	location Location,
+	tabWidth uint,
+) error {
+	state := &layoutState {
+		disp: disp,
+		tabWidth: tabWidth,
+		levels: []uint {0},
+		atLineStart: true,
+	}
+	for {
+		r, size, err := reader.ReadRune()
+		if err == nil {
+			state.feed(r, location)
+			location.NextRune(r, size)
+		} else if err == io.EOF {
+			state.finish(location)
+			break
+		} else {
+			if encodingError, ok := err.(*EncodingError); ok {
+				encodingError.Location = location
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func(symbol LayoutSymbol) String() string {
+	switch symbol.Kind {
+		case LAYOUT_RUNE:
+			return strconv.QuoteRune(symbol.Rune)
+		case LAYOUT_NEWLINE:
+			return "end of line"
+		case LAYOUT_INDENT:
+			return "indentation"
+		case LAYOUT_DEDENT:
+			return "dedentation"
+		case LAYOUT_BAD_DEDENT:
+			return "dedentation to no enclosing indentation level"
+		default:
+			return "<unknown layout symbol>"
+	}
+}
+
+func FormatLayoutPacket(packet *Packet[Locatable[LayoutSymbol]]) string {
+	return FormatPacket(packet)
+}
+
+func layoutToken[ExpectT any](
+	formatPacket func(*Packet[Locatable[LayoutSymbol]]) string,
+	expected ExpectT,
+	formatExpected func(ExpectT) string,
+	kind LayoutKind,
+	description string,
+) Rule[Locatable[LayoutSymbol], *Packet[Locatable[LayoutSymbol]], ExpectT] {
+	if formatExpected == nil {
+		formatExpected = func(ExpectT) string {
+			return description
+		}
+	}
+	return SingleToken(formatPacket, expected, formatExpected, func(packet *Packet[Locatable[LayoutSymbol]]) bool {
+		return !packet.EOF && packet.Item.Symbol.Kind == kind
+	})
+}
+
+func Newline[ExpectT any](
+	formatPacket func(*Packet[Locatable[LayoutSymbol]]) string,
+	expected ExpectT,
+	formatExpected func(ExpectT) string,
+) Rule[Locatable[LayoutSymbol], *Packet[Locatable[LayoutSymbol]], ExpectT] {
+	return layoutToken(formatPacket, expected, formatExpected, LAYOUT_NEWLINE, "end of line")
+}
+
+func Indent[ExpectT any](
+	formatPacket func(*Packet[Locatable[LayoutSymbol]]) string,
+	expected ExpectT,
+	formatExpected func(ExpectT) string,
+) Rule[Locatable[LayoutSymbol], *Packet[Locatable[LayoutSymbol]], ExpectT] {
+	return layoutToken(formatPacket, expected, formatExpected, LAYOUT_INDENT, "indented block")
+}
+
+func Dedent[ExpectT any](
+	formatPacket func(*Packet[Locatable[LayoutSymbol]]) string,
+	expected ExpectT,
+	formatExpected func(ExpectT) string,
+) Rule[Locatable[LayoutSymbol], *Packet[Locatable[LayoutSymbol]], ExpectT] {
+	return layoutToken(formatPacket, expected, formatExpected, LAYOUT_DEDENT, "end of indented block")
+}
+
+func LayoutRune[ExpectT any](
+	formatPacket func(*Packet[Locatable[LayoutSymbol]]) string,
+	expected ExpectT,
+	formatExpected func(ExpectT) string,
+	predicate func(rune) bool,
+) Rule[Locatable[LayoutSymbol], *Packet[Locatable[LayoutSymbol]], ExpectT] {
+	return SingleToken(formatPacket, expected, formatExpected, func(packet *Packet[Locatable[LayoutSymbol]]) bool {
+		symbol := packet.Item.Symbol
+		return !packet.EOF && symbol.Kind == LAYOUT_RUNE && predicate != nil && predicate(symbol.Rune)
+	})
+}
+
+var ErrBadDedent = errors.New("Dedentation does not line up with any enclosing indentation level")
+
+func layoutBadDedent[OutT any, ExpectT any](
+	result *Result[Locatable[LayoutSymbol], OutT, ExpectT],
+) *Packet[Locatable[LayoutSymbol]] {
+	packet := result.Reader.Current()
+	if result.Error != nil {
+		packet = result.Error.Near()
+	}
+	if packet == nil || packet.EOF || packet.Item.Symbol.Kind != LAYOUT_BAD_DEDENT {
+		return nil
+	}
+	return packet
+}
+
+func Block[OutT any, ExpectT any](
+	formatPacket func(*Packet[Locatable[LayoutSymbol]]) string,
+	expected ExpectT,
+	formatExpected func(ExpectT) string,
+	rule Rule[Locatable[LayoutSymbol], OutT, ExpectT],
+) Rule[Locatable[LayoutSymbol], OutT, ExpectT] {
+	indent := Indent(formatPacket, expected, formatExpected)
+	dedent := Dedent(formatPacket, expected, formatExpected)
+	return func(reader *Reader[Locatable[LayoutSymbol]], resultChannel ResultChannel[Locatable[LayoutSymbol], OutT, ExpectT]) {
+		if debugOn {
+			debugf("Entering Block with Reader %s\n", debugReader(reader))
+		}
+		var value OutT
+		var result *Result[Locatable[LayoutSymbol], OutT, ExpectT]
+		indentResult := RunRule(indent, reader)
+		if indentResult.Error != nil {
+			result = SubstResult(indentResult, value)
+		} else {
+			if rule == nil {
+				result = missingRuleResult[Locatable[LayoutSymbol], OutT, ExpectT]("Block", formatPacket, indentResult.Reader)
+			} else {
+				result = RunRule(rule, indentResult.Reader)
+			}
+			if badDedent := layoutBadDedent(result); badDedent != nil {
+				if debugOn {
+					debugf("[Block with Reader %s] Body ran into a bad dedent\n", debugReader(reader))
+				}
+				if result.Error == nil {
+					// the body was fine, so it is up to us to let go of the reader
+					result.Reader.AcknowledgeOnChannel(ACK_UNSUBSCRIBE_ON_ERROR)
+				}
+				result = &Result[Locatable[LayoutSymbol], OutT, ExpectT] {
+					Offset: badDedent.Offset,
+					Result: value,
+					Error: &SyntaxError[Locatable[LayoutSymbol], ExpectT] {
+						Found: badDedent,
+						FormatFound: formatPacket,
+						Cause: ErrBadDedent,
+					},
+					Reader: result.Reader,
+				}
+			} else if result.Error == nil {
+				value = result.Result
+				result = SubstResult(RunRule(dedent, result.Reader), value)
+			}
+		}
+		if debugOn {
+			debugf("[Block with Reader %s] Issuing %s\n", debugReader(reader), debugResult(result))
+		}
+		resultChannel <- result
+		if debugOn {
+			debugf("Leaving Block with Reader %s\n", debugReader(reader))
+		}
+	}
+}
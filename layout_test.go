@@ -0,0 +1,181 @@
+package gorecdesc
+
+import (
+	"errors"
+	"strings"
+	"time"
+	tst "testing"
+	. "github.com/UncleSniper/gotest"
+)
+
+type testLayout = Locatable[LayoutSymbol]
+
+func parseTestLayout[OutT any](
+	rule Rule[testLayout, OutT, string],
+	input string,
+) *Result[testLayout, OutT, string] {
+	var disp Dispatcher[testLayout]
+	reader := disp.Subscribe()
+	resultChannel := make(chan *Result[testLayout, OutT, string])
+	go func() {
+		reader.Next()
+		result := RunRule(rule, reader)
+		result.Reader.AcknowledgeOnChannel(ACK_UNSUBSCRIBE_ON_SUCCESS)
+		resultChannel <- result
+	}()
+	go SendLayout(&disp, strings.NewReader(input), StartOfFile("test"), 8)
+	result := <-resultChannel
+	disp.Close()
+	return result
+}
+
+func layoutTestKinds(input string) (string, error) {
+	var disp Dispatcher[testLayout]
+	reader := disp.Subscribe()
+	kinds := make(chan string)
+	go func() {
+		var builder strings.Builder
+		for packet := reader.Next(); !packet.EOF; packet = reader.Next() {
+			symbol := packet.Item.Symbol
+			switch symbol.Kind {
+				case LAYOUT_RUNE:
+					builder.WriteRune(symbol.Rune)
+				case LAYOUT_NEWLINE:
+					builder.WriteString(";")
+				case LAYOUT_INDENT:
+					builder.WriteString("{")
+				case LAYOUT_DEDENT:
+					builder.WriteString("}")
+				case LAYOUT_BAD_DEDENT:
+					builder.WriteString("!")
+			}
+			reader.Acknowledge(ACK_KEEP_SUBSCRIPTION)
+		}
+		reader.AcknowledgeOnChannel(ACK_UNSUBSCRIBE_ON_SUCCESS)
+		kinds <- builder.String()
+	}()
+	err := SendLayout(&disp, strings.NewReader(input), StartOfFile("test"), 4)
+	return <-kinds, err
+}
+
+func TestSendLayout(t *tst.T) {
+	c := Use(t)
+	kinds, err := layoutTestKinds("a\n  b\n\n\tc\n   d\ne")
+	AssertThat(c, err).Is(ZeroValue[error]())
+	AssertThat(c, kinds).Is(EqualTo("a;{b;{c;!d;}}e;"))
+}
+
+func TestSendLayoutCRLF(t *tst.T) {
+	c := Use(t)
+	kinds, err := layoutTestKinds("a\r\n  b\r\n\r\nc\rd\r")
+	AssertThat(c, err).Is(ZeroValue[error]())
+	AssertThat(c, kinds).Is(EqualTo("a;{b;}c\rd\r;"))
+}
+
+func TestBlock(t *tst.T) {
+	c := Use(t)
+	letter := func(r rune) Rule[testLayout, *Packet[testLayout], string] {
+		return LayoutRune[string](nil, string(r), nil, func(have rune) bool {
+			return have == r
+		})
+	}
+	newline := Newline[string](nil, "", nil)
+	line := func(r rune) Rule[testLayout, int, string] {
+		return Sequence[testLayout, int, *Packet[testLayout], string](
+			The(1),
+			nil,
+			letter(r),
+			newline,
+		)
+	}
+	sum := func(total int, piece int) int {
+		return total + piece
+	}
+	body := Repetition[testLayout, int, int, *Packet[testLayout], string](
+		nil,
+		The(0),
+		func(total int, separator *Packet[testLayout], item int) int {
+			return total + item
+		},
+		"",
+		nil,
+		line('b'),
+		nil,
+		1,
+		100,
+		false,
+	)
+	rule := Sequence[testLayout, int, int, string](
+		The(0),
+		sum,
+		line('a'),
+		Block[int, string](FormatLayoutPacket, "", nil, body),
+		line('c'),
+	)
+	result := parseTestLayout(rule, "a\n  b\n  b\nc\n")
+	AssertThat(c, result.Error == nil).Is(EqualTo(true))
+	AssertThat(c, result.Result).Is(EqualTo(4))
+	result = parseTestLayout(rule, "a\n    b\n  b\nc\n")
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage(
+		"Dedentation does not line up with any enclosing indentation level near " +
+				"dedentation to no enclosing indentation level at test:3:3",
+	))
+	AssertThat(c, errors.Is(result.Error, ErrBadDedent)).Is(EqualTo(true))
+	AssertThat(c, result.Error.Near().Item.Location.Line).Is(EqualTo[uint](3))
+}
+
+func TestBlockBadDedentReleasesReader(t *tst.T) {
+	c := Use(t)
+	body := Sequence[testLayout, int, *Packet[testLayout], string](
+		The(0),
+		testCount[*Packet[testLayout]],
+		LayoutRune[string](nil, "b", nil, func(have rune) bool {
+			return have == 'b'
+		}),
+		Newline[string](nil, "", nil),
+	)
+	rule := Block[int, string](nil, "", nil, body)
+	var disp Dispatcher[testLayout]
+	reader := disp.Subscribe()
+	errs := make(chan error)
+	go func() {
+		reader.Next()
+		// no ack here: Block must have let go of the reader itself
+		errs <- RunRule(rule, reader).Error
+	}()
+	sent := make(chan error)
+	go func() {
+		sent <- SendLayout(&disp, strings.NewReader("    b\n  c\n"), StartOfFile("test"), 8)
+	}()
+	AssertThat(c, errors.Is(<-errs, ErrBadDedent)).Is(EqualTo(true))
+	select {
+		case err := <-sent:
+			AssertThat(c, err).Is(ZeroValue[error]())
+		case <-time.After(5 * time.Second):
+			t.Fatal("SendLayout did not return")
+	}
+	AssertThat(c, disp.Stats().LiveSubscriptions).Is(EqualTo(0))
+	disp.Close()
+}
+
+func TestFormatPacketLayoutSymbol(t *tst.T) {
+	c := Use(t)
+	packet := func(kind LayoutKind, r rune) *Packet[testLayout] {
+		return &Packet[testLayout] {
+			Item: testLayout {
+				Symbol: LayoutSymbol {
+					Kind: kind,
+					Rune: r,
+				},
+				Location: StartOfFile("test"),
+			},
+		}
+	}
+	AssertThat(c, FormatPacket(packet(LAYOUT_RUNE, 'a'))).Is(EqualTo("'a' at test:1:1"))
+	AssertThat(c, FormatPacket(packet(LAYOUT_NEWLINE, 0))).Is(EqualTo("end of line at test:1:1"))
+	AssertThat(c, FormatPacket(packet(LAYOUT_INDENT, 0))).Is(EqualTo("indentation at test:1:1"))
+	AssertThat(c, FormatLayoutPacket(packet(LAYOUT_DEDENT, 0))).Is(EqualTo("dedentation at test:1:1"))
+	AssertThat(c, LayoutSymbol {Kind: LAYOUT_BAD_DEDENT}.String()).Is(EqualTo(
+		"dedentation to no enclosing indentation level",
+	))
+}
@@ -0,0 +1,138 @@
+package gorecdesc
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+func runesEqualFold(left rune, right rune) bool {
+	if left == right {
+		return true
+	}
+	for folded := unicode.SimpleFold(left); folded != left; folded = unicode.SimpleFold(folded) {
+		if folded == right {
+			return true
+		}
+	}
+	return false
+}
+
+func isIdentifierRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func literalRule[ExpectT any](
+	formatPacket func(*Packet[Locatable[rune]]) string,
+	expected ExpectT,
+	formatExpected func(ExpectT) string,
+	literal string,
+	fold bool,
+	keyword bool,
+) Rule[Locatable[rune], RangeLocatable[string], ExpectT] {
+	if formatExpected == nil {
+		quoted := strconv.Quote(literal)
+		formatExpected = func(ExpectT) string {
+			return quoted
+		}
+	}
+	return func(reader *Reader[Locatable[rune]], resultChannel ResultChannel[Locatable[rune], RangeLocatable[string], ExpectT]) {
+		if debugOn {
+			debugf("Entering Literal %q with Reader %s\n", literal, debugReader(reader))
+		}
+		scanner := newPacketScanner(reader)
+		matched := true
+		var builder strings.Builder
+		for _, want := range literal {
+			current := scanner.current()
+			if current.EOF {
+				matched = false
+				break
+			}
+			have := current.Item.Symbol
+			if have != want && !(fold && runesEqualFold(have, want)) {
+				matched = false
+				break
+			}
+			builder.WriteRune(have)
+			scanner.advance()
+		}
+		if matched && keyword {
+			// a keyword must not run on into an identifier
+			if current := scanner.current(); !current.EOF && isIdentifierRune(current.Item.Symbol) {
+				matched = false
+			}
+		}
+		var result *Result[Locatable[rune], RangeLocatable[string], ExpectT]
+		if matched {
+			end := scanner.current()
+			result = &Result[Locatable[rune], RangeLocatable[string], ExpectT] {
+				Offset: end.Offset,
+				Result: RangeLocatable[string] {
+					Symbol: builder.String(),
+					Start: scanner.start.Item.Location,
+					End: end.Item.Location,
+				},
+				Reader: reader,
+			}
+		} else {
+			if debugOn {
+				debugf("[Literal %q with Reader %s] No match\n", literal, debugReader(reader))
+			}
+			scanner.reject()
+			result = &Result[Locatable[rune], RangeLocatable[string], ExpectT] {
+				Offset: scanner.start.Offset,
+				Error: &SyntaxError[Locatable[rune], ExpectT] {
+					Found: scanner.start,
+					Expected: []ExpectT {expected},
+					FormatFound: formatPacket,
+					FormatExpected: formatExpected,
+				},
+				Reader: reader,
+			}
+		}
+		if debugOn {
+			debugf("[Literal %q with Reader %s] Issuing %s\n", literal, debugReader(reader), debugResult(result))
+		}
+		resultChannel <- result
+		if debugOn {
+			debugf("Leaving Literal %q with Reader %s\n", literal, debugReader(reader))
+		}
+	}
+}
+
+func Literal[ExpectT any](
+	formatPacket func(*Packet[Locatable[rune]]) string,
+	expected ExpectT,
+	formatExpected func(ExpectT) string,
+	literal string,
+) Rule[Locatable[rune], RangeLocatable[string], ExpectT] {
+	return literalRule(formatPacket, expected, formatExpected, literal, false, false)
+}
+
+func LiteralFold[ExpectT any](
+	formatPacket func(*Packet[Locatable[rune]]) string,
+	expected ExpectT,
+	formatExpected func(ExpectT) string,
+	literal string,
+) Rule[Locatable[rune], RangeLocatable[string], ExpectT] {
+	return literalRule(formatPacket, expected, formatExpected, literal, true, false)
+}
+
+func Keyword[ExpectT any](
+	formatPacket func(*Packet[Locatable[rune]]) string,
+	expected ExpectT,
+	formatExpected func(ExpectT) string,
+	keyword string,
+) Rule[Locatable[rune], RangeLocatable[string], ExpectT] {
+	return literalRule(formatPacket, expected, formatExpected, keyword, false, true)
+}
+
+func KeywordFold[ExpectT any](
+	formatPacket func(*Packet[Locatable[rune]]) string,
+	expected ExpectT,
+	formatExpected func(ExpectT) string,
+	keyword string,
+) Rule[Locatable[rune], RangeLocatable[string], ExpectT] {
+	return literalRule(formatPacket, expected, formatExpected, keyword, true, true)
+}
@@ -0,0 +1,38 @@
+package gorecdesc
+
+import (
+	tst "testing"
+	. "github.com/UncleSniper/gotest"
+)
+
+func TestLiteral(t *tst.T) {
+	c := Use(t)
+	rule := Literal[string](nil, "while", nil, "while")
+	result, _ := parseTestInput(rule, "while(", 2)
+	AssertThat(c, result.Error == nil).Is(EqualTo(true))
+	AssertThat(c, result.Result.Symbol).Is(EqualTo("while"))
+	AssertThat(c, result.Offset).Is(EqualTo[uint64](5))
+	result, _ = parseTestInput(rule, "whale", 2)
+	AssertThat(c, result.Offset).Is(EqualTo[uint64](0))
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage(`Expected "while" near 'w' at test:1:1`))
+}
+
+func TestLiteralFold(t *tst.T) {
+	c := Use(t)
+	result, _ := parseTestInput(LiteralFold[string](nil, "", nil, "select"), "SeLeCt *", 1)
+	AssertThat(c, result.Error == nil).Is(EqualTo(true))
+	AssertThat(c, result.Result.Symbol).Is(EqualTo("SeLeCt"))
+}
+
+func TestKeyword(t *tst.T) {
+	c := Use(t)
+	rule := Keyword[string](nil, "", nil, "if")
+	result, _ := parseTestInput(rule, "if(", 1)
+	AssertThat(c, result.Error == nil).Is(EqualTo(true))
+	result, _ = parseTestInput(rule, "if", 1)
+	AssertThat(c, result.Error == nil).Is(EqualTo(true))
+	result, _ = parseTestInput(rule, "iffy", 1)
+	AssertThat(c, result.Error == nil).Is(EqualTo(false))
+	result, _ = parseTestInput(KeywordFold[string](nil, "", nil, "if"), "IF_", 1)
+	AssertThat(c, result.Error == nil).Is(EqualTo(false))
+}
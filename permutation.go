@@ -0,0 +1,129 @@
+package gorecdesc
+
+import (
+	"errors"
+	"math"
+)
+
+type PermutationEntry[ReadT any, OutT any, ExpectT any] struct {
+	Rule Rule[ReadT, OutT, ExpectT]
+	Expected ExpectT
+	Required bool
+}
+
+type PermutationSlot[OutT any] struct {
+	Value OutT
+	Present bool
+}
+
+type permutationPiece[OutT any] struct {
+	slot int
+	value OutT
+}
+
+func Permutation[ReadT any, OutT any, SeparatorT any, ExpectT any](
+	structure string,
+	formatPacket func(*Packet[ReadT]) string,
+	compareExpect func(ExpectT, ExpectT) bool,
+	formatExpected func(ExpectT) string,
+	separatorRule Rule[ReadT, SeparatorT, ExpectT],
+	entries ...PermutationEntry[ReadT, OutT, ExpectT],
+) Rule[ReadT, []PermutationSlot[OutT], ExpectT] {
+	var choices []Rule[ReadT, permutationPiece[OutT], ExpectT]
+	for slot, entry := range entries {
+		if entry.Rule == nil {
+			continue
+		}
+		slot := slot
+		choices = append(choices, MapRule(
+			formatPacket,
+			entry.Expected,
+			formatExpected,
+			entry.Rule,
+			func(value OutT) permutationPiece[OutT] {
+				return permutationPiece[OutT] {
+					slot: slot,
+					value: value,
+				}
+			},
+		))
+	}
+	var noChoice ExpectT
+	pieces := TryRepetition(
+		formatPacket,
+		func() []PermutationSlot[OutT] {
+			return make([]PermutationSlot[OutT], len(entries))
+		},
+		func(
+			slots []PermutationSlot[OutT],
+			separator SeparatorT,
+			piece permutationPiece[OutT],
+		) ([]PermutationSlot[OutT], error) {
+			if slots[piece.slot].Present {
+				description := "entry"
+				if formatExpected != nil {
+					description = formatExpected(entries[piece.slot].Expected)
+				}
+				return slots, errors.New("Duplicate " + description)
+			}
+			slots[piece.slot] = PermutationSlot[OutT] {
+				Value: piece.value,
+				Present: true,
+			}
+			return slots, nil
+		},
+		noChoice,
+		nil,
+		Choice(structure, formatPacket, noChoice, nil, compareExpect, formatExpected, choices...),
+		separatorRule,
+		0,
+		math.MaxUint64,
+		false,
+	)
+	return func(reader *Reader[ReadT], resultChannel ResultChannel[ReadT, []PermutationSlot[OutT], ExpectT]) {
+		if debugOn {
+			debugf("Entering Permutation for structure '%s' with Reader %s\n", structure, debugReader(reader))
+		}
+		result := RunRule(pieces, reader)
+		if result.Error == nil {
+			var missing []ExpectT
+			for slot, entry := range entries {
+				if entry.Required && !result.Result[slot].Present {
+					missing = append(missing, entry.Expected)
+				}
+			}
+			if len(missing) > 0 {
+				if debugOn {
+					debugf(
+						"[Permutation with Reader %s] Missing %d required entries, issuing ACK_UNSUBSCRIBE_ON_ERROR\n",
+						debugReader(reader),
+						len(missing),
+					)
+				}
+				result.Reader.AcknowledgeOnChannel(ACK_UNSUBSCRIBE_ON_ERROR)
+				end := result.Reader.Current()
+				result = &Result[ReadT, []PermutationSlot[OutT], ExpectT] {
+					Offset: end.Offset,
+					Result: result.Result,
+					Structure: structure,
+					Error: &SyntaxError[ReadT, ExpectT] {
+						Found: end,
+						Expected: missing,
+						FormatFound: formatPacket,
+						FormatExpected: formatExpected,
+						Committed: COM_COMPLETE,
+						Structure: structure,
+					},
+					Reader: result.Reader,
+				}
+			}
+		}
+		if debugOn {
+			debugf("[Permutation with Reader %s] Issuing %s\n", debugReader(reader), debugResult(result))
+		}
+		resultChannel <- result
+		if debugOn {
+			debugf("Leaving Permutation for structure '%s' with Reader %s\n", structure, debugReader(reader))
+		}
+	}
+}
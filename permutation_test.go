@@ -0,0 +1,67 @@
+package gorecdesc
+
+import (
+	tst "testing"
+	. "github.com/UncleSniper/gotest"
+)
+
+func testAttributes() Rule[testRune, []PermutationSlot[*Packet[testRune]], string] {
+	entry := func(r rune, required bool) PermutationEntry[testRune, *Packet[testRune], string] {
+		return PermutationEntry[testRune, *Packet[testRune], string] {
+			Rule: testQuotedRuneToken(r),
+			Expected: string(r),
+			Required: required,
+		}
+	}
+	return Permutation(
+		"attributes",
+		nil,
+		nil,
+		func(expected string) string {
+			return "'" + expected + "'"
+		},
+		testQuotedRuneToken(','),
+		entry('a', true),
+		entry('b', true),
+		entry('c', false),
+	)
+}
+
+func TestPermutation(t *tst.T) {
+	c := Use(t)
+	rule := testAttributes()
+	forEachTestBatchSize(func(batchSize uint) {
+		result, stats := parseTestInput(rule, "b,a,c", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(true))
+		AssertThat(c, len(result.Result)).Is(EqualTo(3))
+		AssertThat(c, result.Result[0].Value.Offset).Is(EqualTo[uint64](2))
+		AssertThat(c, result.Result[1].Value.Offset).Is(EqualTo[uint64](0))
+		AssertThat(c, result.Result[2].Value.Offset).Is(EqualTo[uint64](4))
+		AssertThat(c, stats.LiveSubscriptions).Is(EqualTo(0))
+		result, _ = parseTestInput(rule, "a,b", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(true))
+		AssertThat(c, result.Result[2].Present).Is(EqualTo(false))
+	})
+}
+
+func TestPermutationMissing(t *tst.T) {
+	c := Use(t)
+	rule := testAttributes()
+	result, stats := parseTestInput(rule, "c,a", 1)
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected 'b' near end of input at test:1:4 to complete attributes"))
+	AssertThat(c, result.Offset).Is(EqualTo[uint64](3))
+	AssertThat(c, stats.LiveSubscriptions).Is(EqualTo(0))
+	result, _ = parseTestInput(rule, "", 1)
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected 'a', or 'b' near end of input at test:1:1 to complete attributes"))
+}
+
+func TestPermutationDuplicate(t *tst.T) {
+	c := Use(t)
+	rule := testAttributes()
+	forEachTestBatchSize(func(batchSize uint) {
+		result, stats := parseTestInput(rule, "a,b,a", batchSize)
+		AssertThat[error](c, result.Error).Is(ErrorWithMessage("Duplicate 'a' near 'a' at test:1:5"))
+		AssertThat(c, result.Offset).Is(EqualTo[uint64](4))
+		AssertThat(c, stats.LiveSubscriptions).Is(EqualTo(0))
+	})
+}
@@ -0,0 +1,201 @@
+package gorecdesc
+
+import (
+	"regexp/syntax"
+	"strings"
+)
+
+type regexMachine struct {
+	prog *syntax.Prog
+	threads []uint32
+	next []uint32
+	onList []bool
+}
+
+func newRegexMachine(prog *syntax.Prog) *regexMachine {
+	return &regexMachine {
+		prog: prog,
+		onList: make([]bool, len(prog.Inst)),
+	}
+}
+
+func(machine *regexMachine) addThread(list []uint32, pc uint32, context syntax.EmptyOp) ([]uint32, bool) {
+	if machine.onList[pc] {
+		return list, false
+	}
+	machine.onList[pc] = true
+	inst := &machine.prog.Inst[pc]
+	switch inst.Op {
+		case syntax.InstAlt, syntax.InstAltMatch:
+			var matchedOut, matchedArg bool
+			list, matchedOut = machine.addThread(list, inst.Out, context)
+			list, matchedArg = machine.addThread(list, inst.Arg, context)
+			return list, matchedOut || matchedArg
+		case syntax.InstCapture, syntax.InstNop:
+			return machine.addThread(list, inst.Out, context)
+		case syntax.InstEmptyWidth:
+			if syntax.EmptyOp(inst.Arg) & ^context != 0 {
+				return list, false
+			}
+			return machine.addThread(list, inst.Out, context)
+		case syntax.InstMatch:
+			return list, true
+		case syntax.InstFail:
+			return list, false
+		default:
+			return append(list, pc), false
+	}
+}
+
+func(machine *regexMachine) clearOnList() {
+	for index := range machine.onList {
+		machine.onList[index] = false
+	}
+}
+
+func(machine *regexMachine) start(context syntax.EmptyOp) bool {
+	machine.clearOnList()
+	var matched bool
+	machine.threads, matched = machine.addThread(machine.threads[:0], uint32(machine.prog.Start), context)
+	return matched
+}
+
+func(machine *regexMachine) step(r rune, context syntax.EmptyOp) bool {
+	machine.clearOnList()
+	machine.next = machine.next[:0]
+	matched := false
+	for _, pc := range machine.threads {
+		inst := &machine.prog.Inst[pc]
+		if !regexInstMatchesRune(inst, r) {
+			continue
+		}
+		var threadMatched bool
+		machine.next, threadMatched = machine.addThread(machine.next, inst.Out, context)
+		matched = matched || threadMatched
+	}
+	machine.threads, machine.next = machine.next, machine.threads
+	return matched
+}
+
+func regexInstMatchesRune(inst *syntax.Inst, r rune) bool {
+	switch inst.Op {
+		case syntax.InstRune:
+			return inst.MatchRune(r)
+		case syntax.InstRune1:
+			return r == inst.Rune[0]
+		case syntax.InstRuneAny:
+			return true
+		case syntax.InstRuneAnyNotNL:
+			return r != '\n'
+		default:
+			return false
+	}
+}
+
+func(machine *regexMachine) alive() bool {
+	return len(machine.threads) > 0
+}
+
+func packetRune(packet *Packet[Locatable[rune]]) rune {
+	if packet == nil || packet.EOF {
+		return -1
+	}
+	return packet.Item.Symbol
+}
+
+func compileRegex(pattern string) (*syntax.Prog, error) {
+	parsed, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return nil, err
+	}
+	return syntax.Compile(parsed.Simplify())
+}
+
+func Regex[ExpectT any](
+	formatPacket func(*Packet[Locatable[rune]]) string,
+	expected ExpectT,
+	formatExpected func(ExpectT) string,
+	pattern string,
+) (Rule[Locatable[rune], RangeLocatable[string], ExpectT], error) {
+	prog, err := compileRegex(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if formatExpected == nil {
+		formatExpected = func(ExpectT) string {
+			return "text matching /" + pattern + "/"
+		}
+	}
+	return func(reader *Reader[Locatable[rune]], resultChannel ResultChannel[Locatable[rune], RangeLocatable[string], ExpectT]) {
+		if debugOn {
+			debugf("Entering Regex /%s/ with Reader %s\n", pattern, debugReader(reader))
+		}
+		scanner := newPacketScanner(reader)
+		machine := newRegexMachine(prog)
+		// find the longest match by running all threads in lockstep
+		matchLength := -1
+		// anchors and word boundaries need to see what precedes the match in the stream
+		if machine.start(syntax.EmptyOpContext(packetRune(scanner.previous), packetRune(scanner.current()))) {
+			matchLength = 0
+		}
+		for machine.alive() && !scanner.current().EOF {
+			r := scanner.current().Item.Symbol
+			next := packetRune(scanner.advance())
+			if machine.step(r, syntax.EmptyOpContext(r, next)) {
+				matchLength = len(scanner.consumed)
+			}
+		}
+		var result *Result[Locatable[rune], RangeLocatable[string], ExpectT]
+		if matchLength < 0 {
+			if debugOn {
+				debugf("[Regex /%s/ with Reader %s] No match\n", pattern, debugReader(reader))
+			}
+			scanner.reject()
+			result = &Result[Locatable[rune], RangeLocatable[string], ExpectT] {
+				Offset: scanner.start.Offset,
+				Error: &SyntaxError[Locatable[rune], ExpectT] {
+					Found: scanner.start,
+					Expected: []ExpectT {expected},
+					FormatFound: formatPacket,
+					FormatExpected: formatExpected,
+				},
+				Reader: reader,
+			}
+		} else {
+			end := scanner.accept(matchLength)
+			var builder strings.Builder
+			for _, packet := range scanner.consumed {
+				builder.WriteRune(packet.Item.Symbol)
+			}
+			result = &Result[Locatable[rune], RangeLocatable[string], ExpectT] {
+				Offset: end.Offset,
+				Result: RangeLocatable[string] {
+					Symbol: builder.String(),
+					Start: scanner.start.Item.Location,
+					End: end.Item.Location,
+				},
+				Reader: reader,
+			}
+		}
+		if debugOn {
+			debugf("[Regex /%s/ with Reader %s] Issuing %s\n", pattern, debugReader(reader), debugResult(result))
+		}
+		resultChannel <- result
+		if debugOn {
+			debugf("Leaving Regex /%s/ with Reader %s\n", pattern, debugReader(reader))
+		}
+	}, nil
+}
+
+func MustRegex[ExpectT any](
+	formatPacket func(*Packet[Locatable[rune]]) string,
+	expected ExpectT,
+	formatExpected func(ExpectT) string,
+	pattern string,
+) Rule[Locatable[rune], RangeLocatable[string], ExpectT] {
+	rule, err := Regex(formatPacket, expected, formatExpected, pattern)
+	if err != nil {
+		panic(err)
+	}
+	return rule
+}
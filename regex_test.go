@@ -0,0 +1,86 @@
+package gorecdesc
+
+import (
+	tst "testing"
+	. "github.com/UncleSniper/gotest"
+)
+
+func TestRegexLongestMatch(t *tst.T) {
+	c := Use(t)
+	rule := MustRegex[string](nil, "identifier", nil, `[a-z]+|[a-z]+[0-9]`)
+	forEachTestBatchSize(func(batchSize uint) {
+		result, _ := parseTestInput(rule, "abc1x", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(true))
+		AssertThat(c, result.Result.Symbol).Is(EqualTo("abc1"))
+		AssertThat(c, result.Offset).Is(EqualTo[uint64](4))
+		AssertThat(c, result.Result.Start.Column).Is(EqualTo[uint](1))
+		AssertThat(c, result.Result.End.Column).Is(EqualTo[uint](5))
+	})
+}
+
+func TestRegexReprovidesOvershoot(t *tst.T) {
+	c := Use(t)
+	concat := func(text string, piece RangeLocatable[string]) string {
+		return text + "|" + piece.Symbol
+	}
+	rule := Sequence[testRune, string, RangeLocatable[string], string](
+		The(""),
+		concat,
+		MustRegex[string](nil, "", nil, `ab(cd)?`),
+		MustRegex[string](nil, "", nil, `[a-z]+`),
+	)
+	forEachTestBatchSize(func(batchSize uint) {
+		result, _ := parseTestInput(rule, "abcx", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(true))
+		AssertThat(c, result.Result).Is(EqualTo("|ab|cx"))
+	})
+}
+
+func TestRegexEmptyWidthAndFlags(t *tst.T) {
+	c := Use(t)
+	rule := MustRegex[string](nil, "", nil, `(?i)if\b`)
+	result, _ := parseTestInput(rule, "IF x", 1)
+	AssertThat(c, result.Error == nil).Is(EqualTo(true))
+	AssertThat(c, result.Result.Symbol).Is(EqualTo("IF"))
+	result, _ = parseTestInput(rule, "iffy", 1)
+	AssertThat(c, result.Error == nil).Is(EqualTo(false))
+}
+
+func TestRegexError(t *tst.T) {
+	c := Use(t)
+	rule := MustRegex[string](nil, "number", nil, `[0-9]+`)
+	result, _ := parseTestInput(rule, "x1", 1)
+	AssertThat(c, result.Error == nil).Is(EqualTo(false))
+	AssertThat(c, result.Offset).Is(EqualTo[uint64](0))
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected text matching /[0-9]+/ near 'x' at test:1:1"))
+	_, err := Regex[string](nil, "", nil, `[a-`)
+	AssertThat(c, err == nil).Is(EqualTo(false))
+}
+
+func TestRegexAnchorsAfterStart(t *tst.T) {
+	c := Use(t)
+	concat := func(text string, piece RangeLocatable[string]) string {
+		return text + "|" + piece.Symbol
+	}
+	pair := func(second string) Rule[testRune, string, string] {
+		return Sequence[testRune, string, RangeLocatable[string], string](
+			The(""),
+			concat,
+			MustRegex[string](nil, "", nil, `[ab]+`),
+			MustRegex[string](nil, "", nil, second),
+		)
+	}
+	forEachTestBatchSize(func(batchSize uint) {
+		result, _ := parseTestInput(pair(`\Bx`), "abx", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(true))
+		AssertThat(c, result.Result).Is(EqualTo("|ab|x"))
+		result, _ = parseTestInput(pair(`\bx`), "abx", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(false))
+		result, _ = parseTestInput(pair(`^x`), "abx", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(false))
+		result, _ = parseTestInput(pair(`\Ax`), "abx", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(false))
+		anchored, _ := parseTestInput(MustRegex[string](nil, "", nil, `^ab`), "abx", batchSize)
+		AssertThat(c, anchored.Error == nil).Is(EqualTo(true))
+	})
+}
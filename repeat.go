@@ -0,0 +1,174 @@
+package gorecdesc
+
+import (
+	"math"
+)
+
+func manyItems[ReadT any, ItemT any, SeparatorT any, ExpectT any](
+	formatPacket func(*Packet[ReadT]) string,
+	itemRule Rule[ReadT, ItemT, ExpectT],
+	separatorRule Rule[ReadT, SeparatorT, ExpectT],
+	minItems uint64,
+	maxItems uint64,
+	allowTrailingSeparator bool,
+) Rule[ReadT, []ItemT, ExpectT] {
+	var noItem ExpectT
+	appendItem := AppendItemsBiAccu[SeparatorT, ItemT]()
+	return repetition(
+		formatPacket,
+		EmptySlice[ItemT](),
+		TryBiAccu(appendItem),
+		nil,
+		noItem,
+		nil,
+		itemRule,
+		separatorRule,
+		minItems,
+		maxItems,
+		allowTrailingSeparator,
+	)
+}
+
+func Many[ReadT any, ItemT any, ExpectT any](
+	formatPacket func(*Packet[ReadT]) string,
+	itemRule Rule[ReadT, ItemT, ExpectT],
+) Rule[ReadT, []ItemT, ExpectT] {
+	return manyItems[ReadT, ItemT, int, ExpectT](formatPacket, itemRule, nil, 0, math.MaxUint64, false)
+}
+
+func Many1[ReadT any, ItemT any, ExpectT any](
+	formatPacket func(*Packet[ReadT]) string,
+	itemRule Rule[ReadT, ItemT, ExpectT],
+) Rule[ReadT, []ItemT, ExpectT] {
+	return manyItems[ReadT, ItemT, int, ExpectT](formatPacket, itemRule, nil, 1, math.MaxUint64, false)
+}
+
+func SepBy[ReadT any, ItemT any, SeparatorT any, ExpectT any](
+	formatPacket func(*Packet[ReadT]) string,
+	itemRule Rule[ReadT, ItemT, ExpectT],
+	separatorRule Rule[ReadT, SeparatorT, ExpectT],
+) Rule[ReadT, []ItemT, ExpectT] {
+	return manyItems(formatPacket, itemRule, separatorRule, 0, math.MaxUint64, false)
+}
+
+func SepBy1[ReadT any, ItemT any, SeparatorT any, ExpectT any](
+	formatPacket func(*Packet[ReadT]) string,
+	itemRule Rule[ReadT, ItemT, ExpectT],
+	separatorRule Rule[ReadT, SeparatorT, ExpectT],
+) Rule[ReadT, []ItemT, ExpectT] {
+	return manyItems(formatPacket, itemRule, separatorRule, 1, math.MaxUint64, false)
+}
+
+func SepEndBy[ReadT any, ItemT any, SeparatorT any, ExpectT any](
+	formatPacket func(*Packet[ReadT]) string,
+	itemRule Rule[ReadT, ItemT, ExpectT],
+	separatorRule Rule[ReadT, SeparatorT, ExpectT],
+) Rule[ReadT, []ItemT, ExpectT] {
+	return manyItems(formatPacket, itemRule, separatorRule, 0, math.MaxUint64, true)
+}
+
+func Count[ReadT any, ItemT any, ExpectT any](
+	formatPacket func(*Packet[ReadT]) string,
+	count uint64,
+	itemRule Rule[ReadT, ItemT, ExpectT],
+) Rule[ReadT, []ItemT, ExpectT] {
+	return manyItems[ReadT, ItemT, int, ExpectT](formatPacket, itemRule, nil, count, count, false)
+}
+
+func Between[ReadT any, OpenT any, OutT any, CloseT any, ExpectT any](
+	openRule Rule[ReadT, OpenT, ExpectT],
+	closeRule Rule[ReadT, CloseT, ExpectT],
+	innerRule Rule[ReadT, OutT, ExpectT],
+) Rule[ReadT, OutT, ExpectT] {
+	return Seq3Into(
+		func(open OpenT, inner OutT, close CloseT) OutT {
+			return inner
+		},
+		openRule,
+		innerRule,
+		closeRule,
+	)
+}
+
+func unless[ReadT any, OutT any, StopT any, ExpectT any](
+	formatPacket func(*Packet[ReadT]) string,
+	stopRule Rule[ReadT, StopT, ExpectT],
+	innerRule Rule[ReadT, OutT, ExpectT],
+) Rule[ReadT, OutT, ExpectT] {
+	if stopRule == nil {
+		return innerRule
+	}
+	var none OutT
+	var unmapped ExpectT
+	mappedStopRule := MapRule(nil, unmapped, nil, stopRule, func(StopT) OutT {
+		return none
+	})
+	return func(reader *Reader[ReadT], resultChannel ResultChannel[ReadT, OutT, ExpectT]) {
+		if debugOn {
+			debugf("Entering unless with Reader %s\n", debugReader(reader))
+		}
+		start := reader.Current()
+		var parallel Parallel[ReadT, OutT, ExpectT]
+		split := reader.Split()
+		if debugOn {
+			debugf(
+				"[unless with Reader %s] Adding split Reader %s to Parallel for stop rule\n",
+				debugReader(reader),
+				debugReader(split),
+			)
+		}
+		parallel.Add(split, mappedStopRule)
+		parallel.Add(reader, innerRule)
+		results := parallel.Await()
+		if debugOn {
+			debugf(
+				"[unless with Reader %s] Parallel.Await() returned results: %s\n",
+				debugReader(reader),
+				debugResultList(results),
+			)
+		}
+		stopResult, innerResult := results[0], results[1]
+		result := innerResult
+		if stopResult.Error == nil {
+			// we are only peeking at the stop rule, so it must let go of its reader either way
+			stopResult.Reader.AcknowledgeOnChannel(ACK_UNSUBSCRIBE_ON_SUCCESS)
+			if innerResult.Error == nil {
+				innerResult.Reader.AcknowledgeOnChannel(ACK_UNSUBSCRIBE_ON_ERROR)
+				result = &Result[ReadT, OutT, ExpectT] {
+					Offset: start.Offset,
+					Result: none,
+					Error: &SyntaxError[ReadT, ExpectT] {
+						Found: start,
+						FormatFound: formatPacket,
+						Structure: stopResult.Structure,
+					},
+					Reader: innerResult.Reader,
+				}
+			}
+		}
+		if debugOn {
+			debugf("[unless with Reader %s] Issuing %s\n", debugReader(reader), debugResult(result))
+		}
+		resultChannel <- result
+		if debugOn {
+			debugf("Leaving unless with Reader %s\n", debugReader(result.Reader))
+		}
+	}
+}
+
+func ManyTill[ReadT any, ItemT any, EndT any, ExpectT any](
+	formatPacket func(*Packet[ReadT]) string,
+	itemRule Rule[ReadT, ItemT, ExpectT],
+	endRule Rule[ReadT, EndT, ExpectT],
+) Rule[ReadT, []ItemT, ExpectT] {
+	if itemRule == nil {
+		return Many(formatPacket, itemRule)
+	}
+	return Seq2Into(
+		func(items []ItemT, end EndT) []ItemT {
+			return items
+		},
+		Many(formatPacket, unless(formatPacket, endRule, itemRule)),
+		endRule,
+	)
+}
@@ -0,0 +1,138 @@
+package gorecdesc
+
+import (
+	tst "testing"
+	. "github.com/UncleSniper/gotest"
+)
+
+func testRuneSymbols(packets []*Packet[testRune]) string {
+	var symbols []rune
+	for _, packet := range packets {
+		symbols = append(symbols, packet.Item.Symbol)
+	}
+	return string(symbols)
+}
+
+func TestMany(t *tst.T) {
+	c := Use(t)
+	rule := Many(nil, testQuotedRuneToken('a'))
+	forEachTestBatchSize(func(batchSize uint) {
+		result, _ := parseTestInput(rule, "aaab", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(true))
+		AssertThat(c, testRuneSymbols(result.Result)).Is(EqualTo("aaa"))
+		AssertThat(c, result.Offset).Is(EqualTo[uint64](3))
+		result, _ = parseTestInput(rule, "", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(true))
+		AssertThat(c, len(result.Result)).Is(EqualTo(0))
+	})
+}
+
+func TestMany1(t *tst.T) {
+	c := Use(t)
+	rule := Many1(nil, testQuotedRuneToken('a'))
+	result, _ := parseTestInput(rule, "ab", 1)
+	AssertThat(c, result.Error == nil).Is(EqualTo(true))
+	AssertThat(c, len(result.Result)).Is(EqualTo(1))
+	result, _ = parseTestInput(rule, "b", 1)
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected 'a' near 'b' at test:1:1"))
+}
+
+func TestManyDetectsInfiniteRepetition(t *tst.T) {
+	c := Use(t)
+	rule := Many(nil, Option(nil, nil, testQuotedRuneToken('a')))
+	result, _ := parseTestInput(rule, "aab", 1)
+	_, infinite := result.Error.(*InfiniteRepetitionError[testRune, string])
+	AssertThat(c, infinite).Is(EqualTo(true))
+	AssertThat(c, result.Offset).Is(EqualTo[uint64](2))
+}
+
+func TestSepBy(t *tst.T) {
+	c := Use(t)
+	rule := SepBy(nil, testQuotedRuneToken('a'), testQuotedRuneToken(','))
+	forEachTestBatchSize(func(batchSize uint) {
+		result, _ := parseTestInput(rule, "a,a,a", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(true))
+		AssertThat(c, len(result.Result)).Is(EqualTo(3))
+		result, _ = parseTestInput(rule, "b", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(true))
+		AssertThat(c, len(result.Result)).Is(EqualTo(0))
+		result, _ = parseTestInput(rule, "a,a,", batchSize)
+		AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected 'a' near end of input at test:1:5"))
+		AssertThat(c, result.Offset).Is(EqualTo[uint64](4))
+	})
+}
+
+func TestSepBy1(t *tst.T) {
+	c := Use(t)
+	rule := SepBy1(nil, testQuotedRuneToken('a'), testQuotedRuneToken(','))
+	result, _ := parseTestInput(rule, "a", 1)
+	AssertThat(c, result.Error == nil).Is(EqualTo(true))
+	AssertThat(c, len(result.Result)).Is(EqualTo(1))
+	result, _ = parseTestInput(rule, "", 1)
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected 'a' near end of input at test:1:1"))
+}
+
+func TestSepEndBy(t *tst.T) {
+	c := Use(t)
+	rule := SepEndBy(nil, testQuotedRuneToken('a'), testQuotedRuneToken(','))
+	forEachTestBatchSize(func(batchSize uint) {
+		result, _ := parseTestInput(rule, "a,a,", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(true))
+		AssertThat(c, testRuneSymbols(result.Result)).Is(EqualTo("aa"))
+		AssertThat(c, result.Offset).Is(EqualTo[uint64](4))
+		result, _ = parseTestInput(rule, "a,a", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(true))
+		AssertThat(c, testRuneSymbols(result.Result)).Is(EqualTo("aa"))
+		AssertThat(c, result.Offset).Is(EqualTo[uint64](3))
+	})
+}
+
+func TestCount(t *tst.T) {
+	c := Use(t)
+	result, _ := parseTestInput(Count(nil, 2, testQuotedRuneToken('a')), "aaa", 1)
+	AssertThat(c, result.Error == nil).Is(EqualTo(true))
+	AssertThat(c, len(result.Result)).Is(EqualTo(2))
+	AssertThat(c, result.Offset).Is(EqualTo[uint64](2))
+	result, _ = parseTestInput(Count(nil, 0, testQuotedRuneToken('a')), "aaa", 1)
+	AssertThat(c, result.Error == nil).Is(EqualTo(true))
+	AssertThat(c, len(result.Result)).Is(EqualTo(0))
+	AssertThat(c, result.Offset).Is(EqualTo[uint64](0))
+	result, _ = parseTestInput(Count(nil, 3, testQuotedRuneToken('a')), "aab", 1)
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected 'a' near 'b' at test:1:3"))
+	AssertThat(c, result.Offset).Is(EqualTo[uint64](2))
+}
+
+func TestBetween(t *tst.T) {
+	c := Use(t)
+	rule := Between(testQuotedRuneToken('('), testQuotedRuneToken(')'), Many(nil, testQuotedRuneToken('a')))
+	result, _ := parseTestInput(rule, "(aa)", 2)
+	AssertThat(c, result.Error == nil).Is(EqualTo(true))
+	AssertThat(c, len(result.Result)).Is(EqualTo(2))
+	AssertThat(c, result.Offset).Is(EqualTo[uint64](4))
+	result, _ = parseTestInput(rule, "(aa", 2)
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected ')' near end of input at test:1:4"))
+}
+
+func TestManyTill(t *tst.T) {
+	c := Use(t)
+	anything := SingleToken[testRune, string](nil, "anything", nil, func(packet *Packet[testRune]) bool {
+		return !packet.EOF
+	})
+	end := Sequence[testRune, int, *Packet[testRune], string](
+		The(0),
+		testCount[*Packet[testRune]],
+		testQuotedRuneToken('*'),
+		testQuotedRuneToken('/'),
+	)
+	rule := ManyTill(nil, anything, end)
+	forEachTestBatchSize(func(batchSize uint) {
+		result, stats := parseTestInput(rule, "ab*c*/d", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(true))
+		AssertThat(c, testRuneSymbols(result.Result)).Is(EqualTo("ab*c"))
+		AssertThat(c, result.Offset).Is(EqualTo[uint64](6))
+		AssertThat(c, stats.LiveSubscriptions).Is(EqualTo(0))
+		result, _ = parseTestInput(rule, "ab*c", batchSize)
+		AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected '*' near end of input at test:1:5"))
+		AssertThat(c, result.Offset).Is(EqualTo[uint64](4))
+	})
+}
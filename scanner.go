@@ -0,0 +1,62 @@
+package gorecdesc
+
+type packetScanner[ReadT any] struct {
+	reader *Reader[ReadT]
+	start *Packet[ReadT]
+	previous *Packet[ReadT]
+	consumed []*Packet[ReadT]
+}
+
+func newPacketScanner[ReadT any](reader *Reader[ReadT]) *packetScanner[ReadT] {
+	return &packetScanner[ReadT] {
+		reader: reader,
+		start: reader.Current(),
+		previous: reader.Previous(),
+	}
+}
+
+func(scanner *packetScanner[ReadT]) current() *Packet[ReadT] {
+	return scanner.reader.Current()
+}
+
+func(scanner *packetScanner[ReadT]) advance() *Packet[ReadT] {
+	scanner.consumed = append(scanner.consumed, scanner.reader.Current())
+	scanner.reader.Acknowledge(ACK_KEEP_SUBSCRIPTION)
+	return scanner.reader.Next()
+}
+
+func(scanner *packetScanner[ReadT]) accept(count int) *Packet[ReadT] {
+	// hand back whatever we read past the end of the match
+	if count < len(scanner.consumed) {
+		if debugOn {
+			debugf(
+				"[packetScanner with Reader %s] Accepting %d of %d packets, reproviding the rest\n",
+				debugReader(scanner.reader),
+				count,
+				len(scanner.consumed),
+			)
+		}
+		scanner.reader.Reprovide(scanner.consumed[count:], true)
+		scanner.consumed = scanner.consumed[:count]
+		if count > 0 {
+			scanner.reader.previous = scanner.consumed[count - 1]
+		} else {
+			scanner.reader.previous = scanner.previous
+		}
+	}
+	return scanner.reader.Current()
+}
+
+func(scanner *packetScanner[ReadT]) reject() {
+	if debugOn {
+		debugf(
+			"[packetScanner with Reader %s] Rejecting %d packets, issuing ACK_UNSUBSCRIBE_ON_ERROR\n",
+			debugReader(scanner.reader),
+			len(scanner.consumed),
+		)
+	}
+	scanner.reader.Acknowledge(ACK_UNSUBSCRIBE_ON_ERROR)
+	scanner.reader.Reprovide(scanner.consumed, true)
+	scanner.reader.previous = scanner.previous
+	scanner.consumed = nil
+}
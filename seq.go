@@ -0,0 +1,457 @@
+package gorecdesc
+
+type seqRun[ReadT any, OutT any, ExpectT any] struct {
+	name string
+	reader *Reader[ReadT]
+	failure *Result[ReadT, OutT, ExpectT]
+}
+
+func runSeqChild[ReadT any, PieceT any, OutT any, ExpectT any](
+	run *seqRun[ReadT, OutT, ExpectT],
+	childIndex int,
+	child Rule[ReadT, PieceT, ExpectT],
+) PieceT {
+	var piece PieceT
+	if run.failure != nil {
+		return piece
+	}
+	if child == nil {
+		if debugOn {
+			debugf(
+				"[%s with Reader %s] Skipping child %d since the Rule is nil\n",
+				run.name,
+				debugReader(run.reader),
+				childIndex,
+			)
+		}
+		return piece
+	}
+	childResult := RunRule(child, run.reader)
+	if debugOn {
+		debugf(
+			"[%s with Reader %s] Received result = %s from child %d\n",
+			run.name,
+			debugReader(run.reader),
+			debugResult(childResult),
+			childIndex,
+		)
+	}
+	if childResult.Error != nil {
+		var outValue OutT
+		run.failure = SubstResult(childResult, outValue)
+		return piece
+	}
+	run.reader = childResult.Reader
+	return childResult.Result
+}
+
+func(run *seqRun[ReadT, OutT, ExpectT]) issue(
+	resultChannel ResultChannel[ReadT, OutT, ExpectT],
+	construct func() OutT,
+) {
+	result := run.failure
+	if result == nil {
+		result = &Result[ReadT, OutT, ExpectT] {
+			Offset: run.reader.current.Offset,
+			Result: construct(),
+			Reader: run.reader,
+		}
+	}
+	if debugOn {
+		debugf("[%s with Reader %s] Issuing %s\n", run.name, debugReader(run.reader), debugResult(result))
+	}
+	resultChannel <- result
+	if debugOn {
+		debugf("Leaving %s with Reader %s\n", run.name, debugReader(run.reader))
+	}
+}
+
+func Seq2Into[ReadT any, FirstT any, SecondT any, OutT any, ExpectT any](
+	construct func(FirstT, SecondT) OutT,
+	first Rule[ReadT, FirstT, ExpectT],
+	second Rule[ReadT, SecondT, ExpectT],
+) Rule[ReadT, OutT, ExpectT] {
+	return func(reader *Reader[ReadT], resultChannel ResultChannel[ReadT, OutT, ExpectT]) {
+		if debugOn {
+			debugf("Entering Seq2 with Reader %s\n", debugReader(reader))
+		}
+		run := &seqRun[ReadT, OutT, ExpectT] {
+			name: "Seq2",
+			reader: reader,
+		}
+		firstValue := runSeqChild(run, 0, first)
+		secondValue := runSeqChild(run, 1, second)
+		run.issue(resultChannel, func() OutT {
+			var outValue OutT
+			if construct != nil {
+				outValue = construct(firstValue, secondValue)
+			}
+			return outValue
+		})
+	}
+}
+
+func Seq2[ReadT any, FirstT any, SecondT any, ExpectT any](
+	first Rule[ReadT, FirstT, ExpectT],
+	second Rule[ReadT, SecondT, ExpectT],
+) Rule[ReadT, Tuple2[FirstT, SecondT], ExpectT] {
+	return Seq2Into(
+		MakeTuple2[FirstT, SecondT],
+		first,
+		second,
+	)
+}
+
+func Seq3Into[ReadT any, FirstT any, SecondT any, ThirdT any, OutT any, ExpectT any](
+	construct func(FirstT, SecondT, ThirdT) OutT,
+	first Rule[ReadT, FirstT, ExpectT],
+	second Rule[ReadT, SecondT, ExpectT],
+	third Rule[ReadT, ThirdT, ExpectT],
+) Rule[ReadT, OutT, ExpectT] {
+	return func(reader *Reader[ReadT], resultChannel ResultChannel[ReadT, OutT, ExpectT]) {
+		if debugOn {
+			debugf("Entering Seq3 with Reader %s\n", debugReader(reader))
+		}
+		run := &seqRun[ReadT, OutT, ExpectT] {
+			name: "Seq3",
+			reader: reader,
+		}
+		firstValue := runSeqChild(run, 0, first)
+		secondValue := runSeqChild(run, 1, second)
+		thirdValue := runSeqChild(run, 2, third)
+		run.issue(resultChannel, func() OutT {
+			var outValue OutT
+			if construct != nil {
+				outValue = construct(firstValue, secondValue, thirdValue)
+			}
+			return outValue
+		})
+	}
+}
+
+func Seq3[ReadT any, FirstT any, SecondT any, ThirdT any, ExpectT any](
+	first Rule[ReadT, FirstT, ExpectT],
+	second Rule[ReadT, SecondT, ExpectT],
+	third Rule[ReadT, ThirdT, ExpectT],
+) Rule[ReadT, Tuple3[FirstT, SecondT, ThirdT], ExpectT] {
+	return Seq3Into(
+		MakeTuple3[FirstT, SecondT, ThirdT],
+		first,
+		second,
+		third,
+	)
+}
+
+func Seq4Into[ReadT any, FirstT any, SecondT any, ThirdT any, FourthT any, OutT any, ExpectT any](
+	construct func(FirstT, SecondT, ThirdT, FourthT) OutT,
+	first Rule[ReadT, FirstT, ExpectT],
+	second Rule[ReadT, SecondT, ExpectT],
+	third Rule[ReadT, ThirdT, ExpectT],
+	fourth Rule[ReadT, FourthT, ExpectT],
+) Rule[ReadT, OutT, ExpectT] {
+	return func(reader *Reader[ReadT], resultChannel ResultChannel[ReadT, OutT, ExpectT]) {
+		if debugOn {
+			debugf("Entering Seq4 with Reader %s\n", debugReader(reader))
+		}
+		run := &seqRun[ReadT, OutT, ExpectT] {
+			name: "Seq4",
+			reader: reader,
+		}
+		firstValue := runSeqChild(run, 0, first)
+		secondValue := runSeqChild(run, 1, second)
+		thirdValue := runSeqChild(run, 2, third)
+		fourthValue := runSeqChild(run, 3, fourth)
+		run.issue(resultChannel, func() OutT {
+			var outValue OutT
+			if construct != nil {
+				outValue = construct(firstValue, secondValue, thirdValue, fourthValue)
+			}
+			return outValue
+		})
+	}
+}
+
+func Seq4[ReadT any, FirstT any, SecondT any, ThirdT any, FourthT any, ExpectT any](
+	first Rule[ReadT, FirstT, ExpectT],
+	second Rule[ReadT, SecondT, ExpectT],
+	third Rule[ReadT, ThirdT, ExpectT],
+	fourth Rule[ReadT, FourthT, ExpectT],
+) Rule[ReadT, Tuple4[FirstT, SecondT, ThirdT, FourthT], ExpectT] {
+	return Seq4Into(
+		MakeTuple4[FirstT, SecondT, ThirdT, FourthT],
+		first,
+		second,
+		third,
+		fourth,
+	)
+}
+
+func Seq5Into[ReadT any, FirstT any, SecondT any, ThirdT any, FourthT any, FifthT any, OutT any, ExpectT any](
+	construct func(FirstT, SecondT, ThirdT, FourthT, FifthT) OutT,
+	first Rule[ReadT, FirstT, ExpectT],
+	second Rule[ReadT, SecondT, ExpectT],
+	third Rule[ReadT, ThirdT, ExpectT],
+	fourth Rule[ReadT, FourthT, ExpectT],
+	fifth Rule[ReadT, FifthT, ExpectT],
+) Rule[ReadT, OutT, ExpectT] {
+	return func(reader *Reader[ReadT], resultChannel ResultChannel[ReadT, OutT, ExpectT]) {
+		if debugOn {
+			debugf("Entering Seq5 with Reader %s\n", debugReader(reader))
+		}
+		run := &seqRun[ReadT, OutT, ExpectT] {
+			name: "Seq5",
+			reader: reader,
+		}
+		firstValue := runSeqChild(run, 0, first)
+		secondValue := runSeqChild(run, 1, second)
+		thirdValue := runSeqChild(run, 2, third)
+		fourthValue := runSeqChild(run, 3, fourth)
+		fifthValue := runSeqChild(run, 4, fifth)
+		run.issue(resultChannel, func() OutT {
+			var outValue OutT
+			if construct != nil {
+				outValue = construct(firstValue, secondValue, thirdValue, fourthValue, fifthValue)
+			}
+			return outValue
+		})
+	}
+}
+
+func Seq5[ReadT any, FirstT any, SecondT any, ThirdT any, FourthT any, FifthT any, ExpectT any](
+	first Rule[ReadT, FirstT, ExpectT],
+	second Rule[ReadT, SecondT, ExpectT],
+	third Rule[ReadT, ThirdT, ExpectT],
+	fourth Rule[ReadT, FourthT, ExpectT],
+	fifth Rule[ReadT, FifthT, ExpectT],
+) Rule[ReadT, Tuple5[FirstT, SecondT, ThirdT, FourthT, FifthT], ExpectT] {
+	return Seq5Into(
+		MakeTuple5[FirstT, SecondT, ThirdT, FourthT, FifthT],
+		first,
+		second,
+		third,
+		fourth,
+		fifth,
+	)
+}
+
+func Seq6Into[
+	ReadT any,
+	FirstT any,
+	SecondT any,
+	ThirdT any,
+	FourthT any,
+	FifthT any,
+	SixthT any,
+	OutT any,
+	ExpectT any,
+](
+	construct func(FirstT, SecondT, ThirdT, FourthT, FifthT, SixthT) OutT,
+	first Rule[ReadT, FirstT, ExpectT],
+	second Rule[ReadT, SecondT, ExpectT],
+	third Rule[ReadT, ThirdT, ExpectT],
+	fourth Rule[ReadT, FourthT, ExpectT],
+	fifth Rule[ReadT, FifthT, ExpectT],
+	sixth Rule[ReadT, SixthT, ExpectT],
+) Rule[ReadT, OutT, ExpectT] {
+	return func(reader *Reader[ReadT], resultChannel ResultChannel[ReadT, OutT, ExpectT]) {
+		if debugOn {
+			debugf("Entering Seq6 with Reader %s\n", debugReader(reader))
+		}
+		run := &seqRun[ReadT, OutT, ExpectT] {
+			name: "Seq6",
+			reader: reader,
+		}
+		firstValue := runSeqChild(run, 0, first)
+		secondValue := runSeqChild(run, 1, second)
+		thirdValue := runSeqChild(run, 2, third)
+		fourthValue := runSeqChild(run, 3, fourth)
+		fifthValue := runSeqChild(run, 4, fifth)
+		sixthValue := runSeqChild(run, 5, sixth)
+		run.issue(resultChannel, func() OutT {
+			var outValue OutT
+			if construct != nil {
+				outValue = construct(firstValue, secondValue, thirdValue, fourthValue, fifthValue, sixthValue)
+			}
+			return outValue
+		})
+	}
+}
+
+func Seq6[ReadT any, FirstT any, SecondT any, ThirdT any, FourthT any, FifthT any, SixthT any, ExpectT any](
+	first Rule[ReadT, FirstT, ExpectT],
+	second Rule[ReadT, SecondT, ExpectT],
+	third Rule[ReadT, ThirdT, ExpectT],
+	fourth Rule[ReadT, FourthT, ExpectT],
+	fifth Rule[ReadT, FifthT, ExpectT],
+	sixth Rule[ReadT, SixthT, ExpectT],
+) Rule[ReadT, Tuple6[FirstT, SecondT, ThirdT, FourthT, FifthT, SixthT], ExpectT] {
+	return Seq6Into(
+		MakeTuple6[FirstT, SecondT, ThirdT, FourthT, FifthT, SixthT],
+		first,
+		second,
+		third,
+		fourth,
+		fifth,
+		sixth,
+	)
+}
+
+func Seq7Into[
+	ReadT any,
+	FirstT any,
+	SecondT any,
+	ThirdT any,
+	FourthT any,
+	FifthT any,
+	SixthT any,
+	SeventhT any,
+	OutT any,
+	ExpectT any,
+](
+	construct func(FirstT, SecondT, ThirdT, FourthT, FifthT, SixthT, SeventhT) OutT,
+	first Rule[ReadT, FirstT, ExpectT],
+	second Rule[ReadT, SecondT, ExpectT],
+	third Rule[ReadT, ThirdT, ExpectT],
+	fourth Rule[ReadT, FourthT, ExpectT],
+	fifth Rule[ReadT, FifthT, ExpectT],
+	sixth Rule[ReadT, SixthT, ExpectT],
+	seventh Rule[ReadT, SeventhT, ExpectT],
+) Rule[ReadT, OutT, ExpectT] {
+	return func(reader *Reader[ReadT], resultChannel ResultChannel[ReadT, OutT, ExpectT]) {
+		if debugOn {
+			debugf("Entering Seq7 with Reader %s\n", debugReader(reader))
+		}
+		run := &seqRun[ReadT, OutT, ExpectT] {
+			name: "Seq7",
+			reader: reader,
+		}
+		firstValue := runSeqChild(run, 0, first)
+		secondValue := runSeqChild(run, 1, second)
+		thirdValue := runSeqChild(run, 2, third)
+		fourthValue := runSeqChild(run, 3, fourth)
+		fifthValue := runSeqChild(run, 4, fifth)
+		sixthValue := runSeqChild(run, 5, sixth)
+		seventhValue := runSeqChild(run, 6, seventh)
+		run.issue(resultChannel, func() OutT {
+			var outValue OutT
+			if construct != nil {
+				outValue = construct(firstValue, secondValue, thirdValue, fourthValue, fifthValue, sixthValue, seventhValue)
+			}
+			return outValue
+		})
+	}
+}
+
+func Seq7[
+	ReadT any,
+	FirstT any,
+	SecondT any,
+	ThirdT any,
+	FourthT any,
+	FifthT any,
+	SixthT any,
+	SeventhT any,
+	ExpectT any,
+](
+	first Rule[ReadT, FirstT, ExpectT],
+	second Rule[ReadT, SecondT, ExpectT],
+	third Rule[ReadT, ThirdT, ExpectT],
+	fourth Rule[ReadT, FourthT, ExpectT],
+	fifth Rule[ReadT, FifthT, ExpectT],
+	sixth Rule[ReadT, SixthT, ExpectT],
+	seventh Rule[ReadT, SeventhT, ExpectT],
+) Rule[ReadT, Tuple7[FirstT, SecondT, ThirdT, FourthT, FifthT, SixthT, SeventhT], ExpectT] {
+	return Seq7Into(
+		MakeTuple7[FirstT, SecondT, ThirdT, FourthT, FifthT, SixthT, SeventhT],
+		first,
+		second,
+		third,
+		fourth,
+		fifth,
+		sixth,
+		seventh,
+	)
+}
+
+func Seq8Into[
+	ReadT any,
+	FirstT any,
+	SecondT any,
+	ThirdT any,
+	FourthT any,
+	FifthT any,
+	SixthT any,
+	SeventhT any,
+	EighthT any,
+	OutT any,
+	ExpectT any,
+](
+	construct func(FirstT, SecondT, ThirdT, FourthT, FifthT, SixthT, SeventhT, EighthT) OutT,
+	first Rule[ReadT, FirstT, ExpectT],
+	second Rule[ReadT, SecondT, ExpectT],
+	third Rule[ReadT, ThirdT, ExpectT],
+	fourth Rule[ReadT, FourthT, ExpectT],
+	fifth Rule[ReadT, FifthT, ExpectT],
+	sixth Rule[ReadT, SixthT, ExpectT],
+	seventh Rule[ReadT, SeventhT, ExpectT],
+	eighth Rule[ReadT, EighthT, ExpectT],
+) Rule[ReadT, OutT, ExpectT] {
+	return func(reader *Reader[ReadT], resultChannel ResultChannel[ReadT, OutT, ExpectT]) {
+		if debugOn {
+			debugf("Entering Seq8 with Reader %s\n", debugReader(reader))
+		}
+		run := &seqRun[ReadT, OutT, ExpectT] {
+			name: "Seq8",
+			reader: reader,
+		}
+		firstValue := runSeqChild(run, 0, first)
+		secondValue := runSeqChild(run, 1, second)
+		thirdValue := runSeqChild(run, 2, third)
+		fourthValue := runSeqChild(run, 3, fourth)
+		fifthValue := runSeqChild(run, 4, fifth)
+		sixthValue := runSeqChild(run, 5, sixth)
+		seventhValue := runSeqChild(run, 6, seventh)
+		eighthValue := runSeqChild(run, 7, eighth)
+		run.issue(resultChannel, func() OutT {
+			var outValue OutT
+			if construct != nil {
+				outValue = construct(firstValue, secondValue, thirdValue, fourthValue, fifthValue, sixthValue, seventhValue, eighthValue)
+			}
+			return outValue
+		})
+	}
+}
+
+func Seq8[
+	ReadT any,
+	FirstT any,
+	SecondT any,
+	ThirdT any,
+	FourthT any,
+	FifthT any,
+	SixthT any,
+	SeventhT any,
+	EighthT any,
+	ExpectT any,
+](
+	first Rule[ReadT, FirstT, ExpectT],
+	second Rule[ReadT, SecondT, ExpectT],
+	third Rule[ReadT, ThirdT, ExpectT],
+	fourth Rule[ReadT, FourthT, ExpectT],
+	fifth Rule[ReadT, FifthT, ExpectT],
+	sixth Rule[ReadT, SixthT, ExpectT],
+	seventh Rule[ReadT, SeventhT, ExpectT],
+	eighth Rule[ReadT, EighthT, ExpectT],
+) Rule[ReadT, Tuple8[FirstT, SecondT, ThirdT, FourthT, FifthT, SixthT, SeventhT, EighthT], ExpectT] {
+	return Seq8Into(
+		MakeTuple8[FirstT, SecondT, ThirdT, FourthT, FifthT, SixthT, SeventhT, EighthT],
+		first,
+		second,
+		third,
+		fourth,
+		fifth,
+		sixth,
+		seventh,
+		eighth,
+	)
+}
@@ -0,0 +1,125 @@
+package gorecdesc
+
+import (
+	"strconv"
+	tst "testing"
+	. "github.com/UncleSniper/gotest"
+)
+
+func TestSeq3(t *tst.T) {
+	c := Use(t)
+	number := MapRule[testRune, RangeLocatable[string], int, string](
+		nil,
+		"",
+		nil,
+		MustRegex[string](nil, "number", nil, "[0-9]+"),
+		func(digits RangeLocatable[string]) int {
+			value, _ := strconv.Atoi(digits.Symbol)
+			return value
+		},
+	)
+	rule := Seq3(
+		Literal[string](nil, "let", nil, "let "),
+		MustRegex[string](nil, "name", nil, "[a-z]+"),
+		Sequence[testRune, int, int, string](
+			The(0),
+			func(sum int, piece int) int {
+				return sum + piece
+			},
+			MapRule[testRune, *Packet[testRune], int, string](
+				nil,
+				"",
+				nil,
+				testQuotedRuneToken('='),
+				func(*Packet[testRune]) int {
+					return 0
+				},
+			),
+			number,
+		),
+	)
+	forEachTestBatchSize(func(batchSize uint) {
+		result, _ := parseTestInput(rule, "let x=42", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(true))
+		AssertThat(c, result.Result.First.Symbol).Is(EqualTo("let "))
+		AssertThat(c, result.Result.Second.Symbol).Is(EqualTo("x"))
+		AssertThat(c, result.Result.Third).Is(EqualTo(42))
+		AssertThat(c, result.Offset).Is(EqualTo[uint64](8))
+		result, _ = parseTestInput(rule, "let x:42", batchSize)
+		AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected '=' near ':' at test:1:6"))
+		AssertThat(c, result.Offset).Is(EqualTo[uint64](5))
+	})
+}
+
+func TestSeq2IntoSkipsNilChild(t *tst.T) {
+	c := Use(t)
+	rule := Seq2Into(
+		func(first *Packet[testRune], second *Packet[testRune]) string {
+			if second != nil {
+				return "both"
+			}
+			return string(first.Item.Symbol)
+		},
+		testQuotedRuneToken('a'),
+		nil,
+	)
+	result, _ := parseTestInput(rule, "ab", 1)
+	AssertThat(c, result.Error == nil).Is(EqualTo(true))
+	AssertThat(c, result.Result).Is(EqualTo("a"))
+	AssertThat(c, result.Offset).Is(EqualTo[uint64](1))
+}
+
+func TestSeq8(t *tst.T) {
+	c := Use(t)
+	token := testQuotedRuneToken
+	rule := Seq8(token('a'), token('b'), token('c'), token('d'), token('e'), token('f'), token('g'), token('h'))
+	forEachTestBatchSize(func(batchSize uint) {
+		result, _ := parseTestInput(rule, "abcdefgh", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(true))
+		AssertThat(c, result.Result.First.Item.Symbol).Is(EqualTo('a'))
+		AssertThat(c, result.Result.Second.Item.Symbol).Is(EqualTo('b'))
+		AssertThat(c, result.Result.Third.Item.Symbol).Is(EqualTo('c'))
+		AssertThat(c, result.Result.Fourth.Item.Symbol).Is(EqualTo('d'))
+		AssertThat(c, result.Result.Fifth.Item.Symbol).Is(EqualTo('e'))
+		AssertThat(c, result.Result.Sixth.Item.Symbol).Is(EqualTo('f'))
+		AssertThat(c, result.Result.Seventh.Item.Symbol).Is(EqualTo('g'))
+		AssertThat(c, result.Result.Eighth.Item.Symbol).Is(EqualTo('h'))
+		AssertThat(c, result.Offset).Is(EqualTo[uint64](8))
+		result, _ = parseTestInput(rule, "abcdeXgh", batchSize)
+		AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected 'f' near 'X' at test:1:6"))
+		AssertThat(c, result.Offset).Is(EqualTo[uint64](5))
+	})
+}
+
+func TestSeq8Into(t *tst.T) {
+	c := Use(t)
+	token := testQuotedRuneToken
+	rule := Seq8Into(
+		func(a, b, c, d, e, f, g, h *Packet[testRune]) string {
+			return string([]rune {
+				a.Item.Symbol,
+				b.Item.Symbol,
+				c.Item.Symbol,
+				d.Item.Symbol,
+				e.Item.Symbol,
+				f.Item.Symbol,
+				g.Item.Symbol,
+				h.Item.Symbol,
+			})
+		},
+		token('a'),
+		token('b'),
+		token('c'),
+		token('d'),
+		token('e'),
+		token('f'),
+		token('g'),
+		token('h'),
+	)
+	forEachTestBatchSize(func(batchSize uint) {
+		result, _ := parseTestInput(rule, "abcdefgh", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(true))
+		AssertThat(c, result.Result).Is(EqualTo("abcdefgh"))
+		AssertThat(c, result.Offset).Is(EqualTo[uint64](8))
+	})
+}
@@ -0,0 +1,98 @@
+package gorecdesc
+
+type StateCloner interface {
+	CloneState() any
+}
+
+func cloneState(state any) any {
+	if cloner, ok := state.(StateCloner); ok {
+		return cloner.CloneState()
+	}
+	return state
+}
+
+func ReaderState[StateT any, ReadT any](reader *Reader[ReadT]) (StateT, bool) {
+	state, ok := reader.state.(StateT)
+	return state, ok
+}
+
+func GetState[ReadT any, StateT any, ExpectT any]() Rule[ReadT, StateT, ExpectT] {
+	return func(reader *Reader[ReadT], resultChannel ResultChannel[ReadT, StateT, ExpectT]) {
+		state, _ := ReaderState[StateT](reader)
+		result := &Result[ReadT, StateT, ExpectT] {
+			Offset: reader.Current().Offset,
+			Result: state,
+			Reader: reader,
+		}
+		if debugOn {
+			debugf("Entering GetState with Reader %s\n", debugReader(reader))
+			debugf("[GetState with Reader %s] Issuing %s\n", debugReader(reader), debugResult(result))
+			debugf("Leaving GetState with Reader %s\n", debugReader(reader))
+		}
+		resultChannel <- result
+	}
+}
+
+func WithState[ReadT any, OutT any, StateT any, ExpectT any](
+	rule Rule[ReadT, OutT, ExpectT],
+	update func(StateT, OutT) StateT,
+) Rule[ReadT, OutT, ExpectT] {
+	return func(reader *Reader[ReadT], resultChannel ResultChannel[ReadT, OutT, ExpectT]) {
+		if debugOn {
+			debugf("Entering WithState with Reader %s\n", debugReader(reader))
+		}
+		var result *Result[ReadT, OutT, ExpectT]
+		if rule == nil {
+			result = missingRuleResult[ReadT, OutT, ExpectT]("WithState", nil, reader)
+		} else {
+			result = RunRule(rule, reader)
+		}
+		if result.Error == nil && update != nil {
+			// only the Reader that carries on sees the new state
+			state, _ := ReaderState[StateT](result.Reader)
+			result.Reader.state = update(state, result.Result)
+			if debugOn {
+				debugf(
+					"[WithState with Reader %s] Updated state to %+v\n",
+					debugReader(result.Reader),
+					result.Reader.state,
+				)
+			}
+		}
+		if debugOn {
+			debugf("[WithState with Reader %s] Issuing %s\n", debugReader(reader), debugResult(result))
+		}
+		resultChannel <- result
+		if debugOn {
+			debugf("Leaving WithState with Reader %s\n", debugReader(reader))
+		}
+	}
+}
+
+func StateDependent[ReadT any, OutT any, StateT any, ExpectT any](
+	build func(StateT) Rule[ReadT, OutT, ExpectT],
+) Rule[ReadT, OutT, ExpectT] {
+	return func(reader *Reader[ReadT], resultChannel ResultChannel[ReadT, OutT, ExpectT]) {
+		if debugOn {
+			debugf("Entering StateDependent with Reader %s\n", debugReader(reader))
+		}
+		var rule Rule[ReadT, OutT, ExpectT]
+		if build != nil {
+			state, _ := ReaderState[StateT](reader)
+			rule = build(state)
+		}
+		var result *Result[ReadT, OutT, ExpectT]
+		if rule == nil {
+			result = missingRuleResult[ReadT, OutT, ExpectT]("StateDependent", nil, reader)
+		} else {
+			result = RunRule(rule, reader)
+		}
+		if debugOn {
+			debugf("[StateDependent with Reader %s] Issuing %s\n", debugReader(reader), debugResult(result))
+		}
+		resultChannel <- result
+		if debugOn {
+			debugf("Leaving StateDependent with Reader %s\n", debugReader(reader))
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package gorecdesc
+
+import (
+	tst "testing"
+	. "github.com/UncleSniper/gotest"
+)
+
+type testNames map[rune]bool
+
+func(names testNames) CloneState() any {
+	clone := make(testNames, len(names))
+	for name := range names {
+		clone[name] = true
+	}
+	return clone
+}
+
+func testLast[PieceT any](last PieceT, piece PieceT) PieceT {
+	return piece
+}
+
+func TestStateFollowsWinningChoice(t *tst.T) {
+	c := Use(t)
+	setTo := func(value string) func(string, int) string {
+		return func(string, int) string {
+			return value
+		}
+	}
+	long := WithState(Sequence[testRune, int, *Packet[testRune], string](
+		The(0),
+		testCount[*Packet[testRune]],
+		testRuneToken('a'),
+		testRuneToken('b'),
+	), setTo("long"))
+	short := WithState(Sequence[testRune, int, *Packet[testRune], string](
+		The(0),
+		testCount[*Packet[testRune]],
+		testRuneToken('a'),
+	), setTo("short"))
+	rule := Sequence[testRune, string, string, string](
+		The(""),
+		testLast[string],
+		MapRule[testRune, int, string, string](nil, "", nil, Choice[testRune, int, string](
+			"", nil, "", nil, nil, nil, long, short,
+		), nil),
+		GetState[testRune, string, string](),
+	)
+	forEachTestBatchSize(func(batchSize uint) {
+		result, _ := parseTestInput(rule, "ab", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(true))
+		AssertThat(c, result.Result).Is(EqualTo("long"))
+		result, _ = parseTestInput(rule, "ax", batchSize)
+		AssertThat(c, result.Error == nil).Is(EqualTo(true))
+		AssertThat(c, result.Result).Is(EqualTo("short"))
+	})
+}
+
+func TestStateDependent(t *tst.T) {
+	c := Use(t)
+	letter := SingleToken[testRune, string](nil, "letter", nil, TokenPredicate(func(item testRune) bool {
+		return item.Symbol >= 'a' && item.Symbol <= 'z'
+	}))
+	declare := WithState(
+		Sequence[testRune, *Packet[testRune], *Packet[testRune], string](
+			nil,
+			testLast[*Packet[testRune]],
+			testRuneToken('!'),
+			letter,
+		),
+		func(names testNames, packet *Packet[testRune]) testNames {
+			if names == nil {
+				names = testNames{}
+			}
+			names[packet.Item.Symbol] = true
+			return names
+		},
+	)
+	declared := StateDependent(func(names testNames) Rule[testRune, *Packet[testRune], string] {
+		return SingleToken[testRune, string](nil, "declared name", nil, TokenPredicate(func(item testRune) bool {
+			return names[item.Symbol]
+		}))
+	})
+	rule := Repetition[testRune, int, *Packet[testRune], *Packet[testRune], string](
+		nil,
+		The(0),
+		func(count int, separator *Packet[testRune], item *Packet[testRune]) int {
+			return count + 1
+		},
+		"",
+		nil,
+		Choice[testRune, *Packet[testRune], string]("", nil, "", nil, nil, nil, declare, declared),
+		nil,
+		0,
+		100,
+		false,
+	)
+	result, _ := parseTestInput(rule, "!xx!yyx", 1)
+	AssertThat(c, result.Error == nil).Is(EqualTo(true))
+	AssertThat(c, result.Offset).Is(EqualTo[uint64](7))
+	result, _ = parseTestInput(rule, "!xxy", 1)
+	AssertThat(c, result.Offset).Is(EqualTo[uint64](3))
+}
+
+func TestStateDependentWithoutRule(t *tst.T) {
+	c := Use(t)
+	rule := StateDependent(func(names testNames) Rule[testRune, *Packet[testRune], string] {
+		return nil
+	})
+	result, _ := parseTestInput(rule, "a", 1)
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected... something near 'a' at test:1:1"))
+}
@@ -1,5 +1,12 @@
 package gorecdesc
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
 func TokenPredicate[ReadT any](subPredicate func(ReadT) bool) func(*Packet[ReadT]) bool {
 	if subPredicate == nil {
 		return nil
@@ -14,3 +21,178 @@ func TokenReturn[ReadT any]() func(*Packet[ReadT]) ReadT {
 		return packet.Item
 	}
 }
+
+type TokenClass[SymbolT any] struct {
+	Test func(SymbolT) bool
+	Description string
+}
+
+func(class TokenClass[SymbolT]) Matches(packet *Packet[SymbolT]) bool {
+	return packet != nil && !packet.EOF && class.Test != nil && class.Test(packet.Item)
+}
+
+type orderedToken interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+			~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+			~float32 | ~float64 | ~string
+}
+
+func describeToken[SymbolT any](token SymbolT) string {
+	switch value := any(token).(type) {
+		case string:
+			return strconv.Quote(value)
+		case fmt.Stringer:
+			return value.String()
+		default:
+			return fmt.Sprintf("%v", value)
+	}
+}
+
+func describeRune(r rune) string {
+	return strconv.QuoteRune(r)
+}
+
+func describeTokenList[SymbolT any](tokens []SymbolT, describe func(SymbolT) string) string {
+	var builder strings.Builder
+	for index, token := range tokens {
+		if index == len(tokens) - 1 && len(tokens) > 1 {
+			builder.WriteString(", or ")
+		} else if index > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(describe(token))
+	}
+	return builder.String()
+}
+
+func TokenEquals[SymbolT comparable](token SymbolT) TokenClass[SymbolT] {
+	return TokenClass[SymbolT] {
+		Test: func(symbol SymbolT) bool {
+			return symbol == token
+		},
+		Description: describeToken(token),
+	}
+}
+
+func TokenIn[SymbolT comparable](tokens ...SymbolT) TokenClass[SymbolT] {
+	return tokenIn(describeToken[SymbolT], tokens)
+}
+
+func tokenIn[SymbolT comparable](describe func(SymbolT) string, tokens []SymbolT) TokenClass[SymbolT] {
+	set := make(map[SymbolT]bool, len(tokens))
+	for _, token := range tokens {
+		set[token] = true
+	}
+	var description string
+	if len(tokens) == 1 {
+		description = describe(tokens[0])
+	} else {
+		description = "one of " + describeTokenList(tokens, describe)
+	}
+	return TokenClass[SymbolT] {
+		Test: func(symbol SymbolT) bool {
+			return set[symbol]
+		},
+		Description: description,
+	}
+}
+
+func TokenRange[SymbolT orderedToken](low SymbolT, high SymbolT) TokenClass[SymbolT] {
+	return tokenRange(describeToken[SymbolT], low, high)
+}
+
+func tokenRange[SymbolT orderedToken](describe func(SymbolT) string, low SymbolT, high SymbolT) TokenClass[SymbolT] {
+	return TokenClass[SymbolT] {
+		Test: func(symbol SymbolT) bool {
+			return symbol >= low && symbol <= high
+		},
+		Description: describe(low) + " through " + describe(high),
+	}
+}
+
+func AnyToken[SymbolT any]() TokenClass[SymbolT] {
+	return TokenClass[SymbolT] {
+		Test: func(SymbolT) bool {
+			return true
+		},
+		Description: "any token",
+	}
+}
+
+func TokenNot[SymbolT any](class TokenClass[SymbolT]) TokenClass[SymbolT] {
+	return TokenClass[SymbolT] {
+		Test: func(symbol SymbolT) bool {
+			return class.Test == nil || !class.Test(symbol)
+		},
+		Description: "anything but " + class.Description,
+	}
+}
+
+func RuneClass(description string, test func(rune) bool) TokenClass[rune] {
+	return TokenClass[rune] {
+		Test: test,
+		Description: description,
+	}
+}
+
+// rune is just int32, so only these know to describe their tokens as characters
+func RuneEquals(r rune) TokenClass[rune] {
+	class := TokenEquals(r)
+	class.Description = describeRune(r)
+	return class
+}
+
+func RuneIn(runes ...rune) TokenClass[rune] {
+	return tokenIn(describeRune, runes)
+}
+
+func RuneRange(low rune, high rune) TokenClass[rune] {
+	return tokenRange(describeRune, low, high)
+}
+
+func LetterRune() TokenClass[rune] {
+	return RuneClass("letter", unicode.IsLetter)
+}
+
+func DigitRune() TokenClass[rune] {
+	return RuneClass("digit", unicode.IsDigit)
+}
+
+func SpaceRune() TokenClass[rune] {
+	return RuneClass("whitespace", unicode.IsSpace)
+}
+
+func UpperRune() TokenClass[rune] {
+	return RuneClass("uppercase letter", unicode.IsUpper)
+}
+
+func LowerRune() TokenClass[rune] {
+	return RuneClass("lowercase letter", unicode.IsLower)
+}
+
+func PunctRune() TokenClass[rune] {
+	return RuneClass("punctuation", unicode.IsPunct)
+}
+
+func Located[SymbolT any](class TokenClass[SymbolT]) TokenClass[Locatable[SymbolT]] {
+	return TokenClass[Locatable[SymbolT]] {
+		Test: func(item Locatable[SymbolT]) bool {
+			return class.Test != nil && class.Test(item.Symbol)
+		},
+		Description: class.Description,
+	}
+}
+
+func MatchToken[ReadT any, ExpectT any](
+	formatPacket func(*Packet[ReadT]) string,
+	class TokenClass[ReadT],
+	expected ExpectT,
+	formatExpected func(ExpectT) string,
+) Rule[ReadT, *Packet[ReadT], ExpectT] {
+	if formatExpected == nil {
+		formatExpected = func(ExpectT) string {
+			return class.Description
+		}
+	}
+	return SingleToken(formatPacket, expected, formatExpected, class.Matches)
+}
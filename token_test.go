@@ -0,0 +1,52 @@
+package gorecdesc
+
+import (
+	tst "testing"
+	. "github.com/UncleSniper/gotest"
+)
+
+func TestTokenClassDescriptions(t *tst.T) {
+	c := Use(t)
+	AssertThat(c, RuneEquals('a').Description).Is(EqualTo("'a'"))
+	AssertThat(c, TokenEquals("let").Description).Is(EqualTo("\"let\""))
+	AssertThat(c, RuneIn('+', '-', '*').Description).Is(EqualTo("one of '+', '-', or '*'"))
+	AssertThat(c, RuneRange('0', '9').Description).Is(EqualTo("'0' through '9'"))
+	AssertThat(c, TokenNot(RuneEquals('"')).Description).Is(EqualTo("anything but '\"'"))
+	AssertThat(c, TokenEquals(int32(65)).Description).Is(EqualTo("65"))
+	AssertThat(c, TokenRange[int32](1, 9).Description).Is(EqualTo("1 through 9"))
+	AssertThat(c, TokenEquals(byte(10)).Description).Is(EqualTo("10"))
+	AssertThat(c, TokenNot(TokenIn(1, 2)).Description).Is(EqualTo("anything but one of 1, or 2"))
+}
+
+func TestTokenClassMatches(t *tst.T) {
+	c := Use(t)
+	packet := func(symbol rune) *Packet[rune] {
+		return &Packet[rune] {
+			Item: symbol,
+		}
+	}
+	AssertThat(c, RuneRange('0', '9').Matches(packet('5'))).Is(EqualTo(true))
+	AssertThat(c, RuneRange('0', '9').Matches(packet('a'))).Is(EqualTo(false))
+	AssertThat(c, TokenNot(LetterRune()).Matches(packet('5'))).Is(EqualTo(true))
+	AssertThat(c, AnyToken[rune]().Matches(packet('x'))).Is(EqualTo(true))
+	AssertThat(c, AnyToken[rune]().Matches(&Packet[rune] {EOF: true})).Is(EqualTo(false))
+	AssertThat(c, TokenNot(LetterRune()).Matches(&Packet[rune] {EOF: true})).Is(EqualTo(false))
+}
+
+func TestMatchToken(t *tst.T) {
+	c := Use(t)
+	rule := Sequence[testRune, int, *Packet[testRune], string](
+		The(0),
+		testCount[*Packet[testRune]],
+		MatchToken(nil, Located(LetterRune()), "", nil),
+		MatchToken(nil, Located(RuneIn('+', '-')), "", nil),
+		MatchToken(nil, Located(DigitRune()), "", nil),
+	)
+	result, _ := parseTestInput(rule, "x+1", 1)
+	AssertThat(c, result.Error == nil).Is(EqualTo(true))
+	AssertThat(c, result.Result).Is(EqualTo(3))
+	result, _ = parseTestInput(rule, "x*1", 1)
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected one of '+', or '-' near '*' at test:1:2"))
+	result, _ = parseTestInput(rule, "x+", 1)
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected digit near end of input at test:1:3"))
+}
@@ -0,0 +1,262 @@
+package gorecdesc
+
+import (
+	"strings"
+	"unicode"
+)
+
+type TriviaKind uint
+
+const (
+	TRIVIA_WHITESPACE TriviaKind = iota
+	TRIVIA_LINE_COMMENT
+	TRIVIA_BLOCK_COMMENT
+)
+
+type TriviaPiece struct {
+	Kind TriviaKind
+	Text string
+	Start Location
+	End Location
+}
+
+type BlockComment struct {
+	Open string
+	Close string
+	Nested bool
+}
+
+type TriviaSyntax struct {
+	LineComments []string
+	BlockComments []BlockComment
+}
+
+type Lexed[OutT any, TriviaT any] struct {
+	Value OutT
+	Trivia TriviaT
+}
+
+func scanText(scanner *packetScanner[Locatable[rune]], text string) bool {
+	mark := len(scanner.consumed)
+	for _, want := range text {
+		current := scanner.current()
+		if current.EOF || current.Item.Symbol != want {
+			scanner.accept(mark)
+			return false
+		}
+		scanner.advance()
+	}
+	return true
+}
+
+func scanBlockComment(scanner *packetScanner[Locatable[rune]], comment BlockComment) bool {
+	depth := 1
+	for {
+		switch {
+			case scanner.current().EOF:
+				return false
+			case comment.Nested && scanText(scanner, comment.Open):
+				depth++
+			case scanText(scanner, comment.Close):
+				depth--
+				if depth == 0 {
+					return true
+				}
+			default:
+				scanner.advance()
+		}
+	}
+}
+
+func Trivia[ExpectT any](
+	formatPacket func(*Packet[Locatable[rune]]) string,
+	expected ExpectT,
+	formatExpected func(ExpectT) string,
+	syntax TriviaSyntax,
+) Rule[Locatable[rune], []TriviaPiece, ExpectT] {
+	if formatExpected == nil {
+		formatExpected = func(ExpectT) string {
+			return "end of block comment"
+		}
+	}
+	return func(reader *Reader[Locatable[rune]], resultChannel ResultChannel[Locatable[rune], []TriviaPiece, ExpectT]) {
+		if debugOn {
+			debugf("Entering Trivia with Reader %s\n", debugReader(reader))
+		}
+		scanner := newPacketScanner(reader)
+		var pieces []TriviaPiece
+		var unterminated *Packet[Locatable[rune]]
+		for !scanner.current().EOF {
+			start := scanner.current()
+			mark := len(scanner.consumed)
+			var kind TriviaKind
+			if unicode.IsSpace(start.Item.Symbol) {
+				kind = TRIVIA_WHITESPACE
+				for !scanner.current().EOF && unicode.IsSpace(scanner.current().Item.Symbol) {
+					scanner.advance()
+				}
+			} else if triviaLineComment(scanner, syntax.LineComments) {
+				kind = TRIVIA_LINE_COMMENT
+				for !scanner.current().EOF && scanner.current().Item.Symbol != '\n' {
+					scanner.advance()
+				}
+			} else if comment, ok := triviaBlockCommentOpen(scanner, syntax.BlockComments); ok {
+				kind = TRIVIA_BLOCK_COMMENT
+				if !scanBlockComment(scanner, comment) {
+					unterminated = start
+					break
+				}
+			} else {
+				break
+			}
+			var builder strings.Builder
+			for _, packet := range scanner.consumed[mark:] {
+				builder.WriteRune(packet.Item.Symbol)
+			}
+			pieces = append(pieces, TriviaPiece {
+				Kind: kind,
+				Text: builder.String(),
+				Start: start.Item.Location,
+				End: scanner.current().Item.Location,
+			})
+		}
+		var result *Result[Locatable[rune], []TriviaPiece, ExpectT]
+		if unterminated != nil {
+			if debugOn {
+				debugf("[Trivia with Reader %s] Unterminated block comment\n", debugReader(reader))
+			}
+			scanner.reject()
+			result = &Result[Locatable[rune], []TriviaPiece, ExpectT] {
+				Offset: unterminated.Offset,
+				Error: &SyntaxError[Locatable[rune], ExpectT] {
+					Found: unterminated,
+					Expected: []ExpectT {expected},
+					FormatFound: formatPacket,
+					FormatExpected: formatExpected,
+				},
+				Reader: reader,
+			}
+		} else {
+			result = &Result[Locatable[rune], []TriviaPiece, ExpectT] {
+				Offset: scanner.current().Offset,
+				Result: pieces,
+				Reader: reader,
+			}
+		}
+		if debugOn {
+			debugf("[Trivia with Reader %s] Issuing %s\n", debugReader(reader), debugResult(result))
+		}
+		resultChannel <- result
+		if debugOn {
+			debugf("Leaving Trivia with Reader %s\n", debugReader(reader))
+		}
+	}
+}
+
+func triviaLineComment(scanner *packetScanner[Locatable[rune]], lineComments []string) bool {
+	for _, lineComment := range lineComments {
+		if len(lineComment) > 0 && scanText(scanner, lineComment) {
+			return true
+		}
+	}
+	return false
+}
+
+func triviaBlockCommentOpen(
+	scanner *packetScanner[Locatable[rune]],
+	blockComments []BlockComment,
+) (BlockComment, bool) {
+	for _, comment := range blockComments {
+		if len(comment.Open) > 0 && len(comment.Close) > 0 && scanText(scanner, comment.Open) {
+			return comment, true
+		}
+	}
+	return BlockComment{}, false
+}
+
+func LexemeWithTrivia[ReadT any, OutT any, TriviaT any, ExpectT any](
+	trivia Rule[ReadT, TriviaT, ExpectT],
+	rule Rule[ReadT, OutT, ExpectT],
+) Rule[ReadT, Lexed[OutT, TriviaT], ExpectT] {
+	return func(reader *Reader[ReadT], resultChannel ResultChannel[ReadT, Lexed[OutT, TriviaT], ExpectT]) {
+		if debugOn {
+			debugf("Entering LexemeWithTrivia with Reader %s\n", debugReader(reader))
+		}
+		var result *Result[ReadT, Lexed[OutT, TriviaT], ExpectT]
+		if rule == nil {
+			result = missingRuleResult[ReadT, Lexed[OutT, TriviaT], ExpectT]("LexemeWithTrivia", nil, reader)
+		} else {
+			ruleResult := RunRule(rule, reader)
+			result = MapResult(ruleResult, func(value OutT) Lexed[OutT, TriviaT] {
+				return Lexed[OutT, TriviaT] {
+					Value: value,
+				}
+			})
+		}
+		if result.Error == nil && trivia != nil {
+			// skip trailing trivia, so the next token starts right at its first symbol
+			triviaResult := RunRule(trivia, result.Reader)
+			lexed := result.Result
+			lexed.Trivia = triviaResult.Result
+			result = SubstResult(triviaResult, lexed)
+		}
+		if debugOn {
+			debugf("[LexemeWithTrivia with Reader %s] Issuing %s\n", debugReader(reader), debugResult(result))
+		}
+		resultChannel <- result
+		if debugOn {
+			debugf("Leaving LexemeWithTrivia with Reader %s\n", debugReader(reader))
+		}
+	}
+}
+
+func Lexeme[ReadT any, OutT any, TriviaT any, ExpectT any](
+	trivia Rule[ReadT, TriviaT, ExpectT],
+	rule Rule[ReadT, OutT, ExpectT],
+) Rule[ReadT, OutT, ExpectT] {
+	var unmapped ExpectT
+	return MapRule[ReadT, Lexed[OutT, TriviaT], OutT, ExpectT](
+		nil,
+		unmapped,
+		nil,
+		LexemeWithTrivia(trivia, rule),
+		func(lexed Lexed[OutT, TriviaT]) OutT {
+			return lexed.Value
+		},
+	)
+}
+
+func SkipTrivia[ReadT any, OutT any, TriviaT any, ExpectT any](
+	trivia Rule[ReadT, TriviaT, ExpectT],
+	rule Rule[ReadT, OutT, ExpectT],
+) Rule[ReadT, OutT, ExpectT] {
+	return func(reader *Reader[ReadT], resultChannel ResultChannel[ReadT, OutT, ExpectT]) {
+		if debugOn {
+			debugf("Entering SkipTrivia with Reader %s\n", debugReader(reader))
+		}
+		var result *Result[ReadT, OutT, ExpectT]
+		if trivia != nil {
+			// Lexeme only skips trivia after tokens, so whatever precedes the first one is ours
+			triviaResult := RunRule(trivia, reader)
+			if triviaResult.Error != nil {
+				var noResult OutT
+				result = SubstResult(triviaResult, noResult)
+			}
+			reader = triviaResult.Reader
+		}
+		if result == nil {
+			if rule == nil {
+				result = missingRuleResult[ReadT, OutT, ExpectT]("SkipTrivia", nil, reader)
+			} else {
+				result = RunRule(rule, reader)
+			}
+		}
+		if debugOn {
+			debugf("[SkipTrivia with Reader %s] Issuing %s\n", debugReader(reader), debugResult(result))
+		}
+		resultChannel <- result
+		if debugOn {
+			debugf("Leaving SkipTrivia with Reader %s\n", debugReader(reader))
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package gorecdesc
+
+import (
+	tst "testing"
+	. "github.com/UncleSniper/gotest"
+)
+
+var testTriviaSyntax = TriviaSyntax {
+	LineComments: []string {"//"},
+	BlockComments: []BlockComment {
+		{Open: "/*", Close: "*/", Nested: true},
+	},
+}
+
+func TestTrivia(t *tst.T) {
+	c := Use(t)
+	rule := Trivia[string](nil, "", nil, testTriviaSyntax)
+	result, _ := parseTestInput(rule, " // line\n/* a /* b */ c */x", 2)
+	AssertThat(c, result.Error == nil).Is(EqualTo(true))
+	AssertThat(c, result.Offset).Is(EqualTo[uint64](26))
+	pieces := result.Result
+	AssertThat(c, len(pieces)).Is(EqualTo(4))
+	AssertThat(c, pieces[1].Kind).Is(EqualTo(TRIVIA_LINE_COMMENT))
+	AssertThat(c, pieces[1].Text).Is(EqualTo("// line"))
+	AssertThat(c, pieces[3].Kind).Is(EqualTo(TRIVIA_BLOCK_COMMENT))
+	AssertThat(c, pieces[3].Text).Is(EqualTo("/* a /* b */ c */"))
+	AssertThat(c, pieces[3].Start.Line).Is(EqualTo[uint](2))
+}
+
+func TestTriviaStopsBeforeLoneSlash(t *tst.T) {
+	c := Use(t)
+	rule := Trivia[string](nil, "", nil, testTriviaSyntax)
+	result, _ := parseTestInput(rule, "  /x", 1)
+	AssertThat(c, result.Error == nil).Is(EqualTo(true))
+	AssertThat(c, result.Offset).Is(EqualTo[uint64](2))
+}
+
+func TestTriviaUnterminatedBlockComment(t *tst.T) {
+	c := Use(t)
+	rule := Trivia[string](nil, "", nil, testTriviaSyntax)
+	result, _ := parseTestInput(rule, " /* /* */", 1)
+	AssertThat(c, result.Offset).Is(EqualTo[uint64](1))
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected end of block comment near '/' at test:1:2"))
+}
+
+func TestLexemeWithTrivia(t *tst.T) {
+	c := Use(t)
+	trivia := Trivia[string](nil, "", nil, testTriviaSyntax)
+	token := func(text string) Rule[testRune, Lexed[RangeLocatable[string], []TriviaPiece], string] {
+		return LexemeWithTrivia(trivia, Literal[string](nil, text, nil, text))
+	}
+	concat := func(text string, piece Lexed[RangeLocatable[string], []TriviaPiece]) string {
+		return text + piece.Value.Symbol + "#" + string(rune('0' + len(piece.Trivia)))
+	}
+	rule := Sequence[testRune, string, Lexed[RangeLocatable[string], []TriviaPiece], string](
+		The(""),
+		concat,
+		token("a"),
+		token("b"),
+		token("c"),
+	)
+	result, _ := parseTestInput(rule, "a /* x */ b//y\nc", 3)
+	AssertThat(c, result.Error == nil).Is(EqualTo(true))
+	AssertThat(c, result.Result).Is(EqualTo("a#3b#2c#0"))
+	plain := Sequence[testRune, int, RangeLocatable[string], string](
+		The(0),
+		testCount[RangeLocatable[string]],
+		Lexeme(trivia, Literal[string](nil, "a", nil, "a")),
+		Lexeme(trivia, Literal[string](nil, "b", nil, "b")),
+	)
+	count, _ := parseTestInput(plain, "a  b ", 1)
+	AssertThat(c, count.Error == nil).Is(EqualTo(true))
+	AssertThat(c, count.Offset).Is(EqualTo[uint64](5))
+}
+
+func TestSkipTrivia(t *tst.T) {
+	c := Use(t)
+	trivia := Trivia[string](nil, "", nil, testTriviaSyntax)
+	rule := SkipTrivia(trivia, Literal[string](nil, "a", nil, "a"))
+	result, _ := parseTestInput(rule, "/* x */ a", 1)
+	AssertThat(c, result.Error == nil).Is(EqualTo(true))
+	AssertThat(c, result.Offset).Is(EqualTo[uint64](9))
+	AssertThat(c, result.Result.Symbol).Is(EqualTo("a"))
+	result, _ = parseTestInput(rule, " /* x", 1)
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected end of block comment near '/' at test:1:2"))
+}
+
+func TestSkipTriviaWithoutRule(t *tst.T) {
+	c := Use(t)
+	trivia := Trivia[string](nil, "", nil, testTriviaSyntax)
+	rule := SkipTrivia[testRune, int](trivia, nil)
+	result, _ := parseTestInput(rule, " a", 1)
+	AssertThat[error](c, result.Error).Is(ErrorWithMessage("Expected... something near 'a' at test:1:2"))
+}
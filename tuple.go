@@ -0,0 +1,187 @@
+package gorecdesc
+
+type Tuple2[FirstT any, SecondT any] struct {
+	First FirstT
+	Second SecondT
+}
+
+func MakeTuple2[FirstT any, SecondT any](
+	first FirstT,
+	second SecondT,
+) Tuple2[FirstT, SecondT] {
+	return Tuple2[FirstT, SecondT] {
+		First: first,
+		Second: second,
+	}
+}
+
+type Tuple3[FirstT any, SecondT any, ThirdT any] struct {
+	First FirstT
+	Second SecondT
+	Third ThirdT
+}
+
+func MakeTuple3[FirstT any, SecondT any, ThirdT any](
+	first FirstT,
+	second SecondT,
+	third ThirdT,
+) Tuple3[FirstT, SecondT, ThirdT] {
+	return Tuple3[FirstT, SecondT, ThirdT] {
+		First: first,
+		Second: second,
+		Third: third,
+	}
+}
+
+type Tuple4[FirstT any, SecondT any, ThirdT any, FourthT any] struct {
+	First FirstT
+	Second SecondT
+	Third ThirdT
+	Fourth FourthT
+}
+
+func MakeTuple4[FirstT any, SecondT any, ThirdT any, FourthT any](
+	first FirstT,
+	second SecondT,
+	third ThirdT,
+	fourth FourthT,
+) Tuple4[FirstT, SecondT, ThirdT, FourthT] {
+	return Tuple4[FirstT, SecondT, ThirdT, FourthT] {
+		First: first,
+		Second: second,
+		Third: third,
+		Fourth: fourth,
+	}
+}
+
+type Tuple5[FirstT any, SecondT any, ThirdT any, FourthT any, FifthT any] struct {
+	First FirstT
+	Second SecondT
+	Third ThirdT
+	Fourth FourthT
+	Fifth FifthT
+}
+
+func MakeTuple5[FirstT any, SecondT any, ThirdT any, FourthT any, FifthT any](
+	first FirstT,
+	second SecondT,
+	third ThirdT,
+	fourth FourthT,
+	fifth FifthT,
+) Tuple5[FirstT, SecondT, ThirdT, FourthT, FifthT] {
+	return Tuple5[FirstT, SecondT, ThirdT, FourthT, FifthT] {
+		First: first,
+		Second: second,
+		Third: third,
+		Fourth: fourth,
+		Fifth: fifth,
+	}
+}
+
+type Tuple6[FirstT any, SecondT any, ThirdT any, FourthT any, FifthT any, SixthT any] struct {
+	First FirstT
+	Second SecondT
+	Third ThirdT
+	Fourth FourthT
+	Fifth FifthT
+	Sixth SixthT
+}
+
+func MakeTuple6[FirstT any, SecondT any, ThirdT any, FourthT any, FifthT any, SixthT any](
+	first FirstT,
+	second SecondT,
+	third ThirdT,
+	fourth FourthT,
+	fifth FifthT,
+	sixth SixthT,
+) Tuple6[FirstT, SecondT, ThirdT, FourthT, FifthT, SixthT] {
+	return Tuple6[FirstT, SecondT, ThirdT, FourthT, FifthT, SixthT] {
+		First: first,
+		Second: second,
+		Third: third,
+		Fourth: fourth,
+		Fifth: fifth,
+		Sixth: sixth,
+	}
+}
+
+type Tuple7[FirstT any, SecondT any, ThirdT any, FourthT any, FifthT any, SixthT any, SeventhT any] struct {
+	First FirstT
+	Second SecondT
+	Third ThirdT
+	Fourth FourthT
+	Fifth FifthT
+	Sixth SixthT
+	Seventh SeventhT
+}
+
+func MakeTuple7[FirstT any, SecondT any, ThirdT any, FourthT any, FifthT any, SixthT any, SeventhT any](
+	first FirstT,
+	second SecondT,
+	third ThirdT,
+	fourth FourthT,
+	fifth FifthT,
+	sixth SixthT,
+	seventh SeventhT,
+) Tuple7[FirstT, SecondT, ThirdT, FourthT, FifthT, SixthT, SeventhT] {
+	return Tuple7[FirstT, SecondT, ThirdT, FourthT, FifthT, SixthT, SeventhT] {
+		First: first,
+		Second: second,
+		Third: third,
+		Fourth: fourth,
+		Fifth: fifth,
+		Sixth: sixth,
+		Seventh: seventh,
+	}
+}
+
+type Tuple8[
+	FirstT any,
+	SecondT any,
+	ThirdT any,
+	FourthT any,
+	FifthT any,
+	SixthT any,
+	SeventhT any,
+	EighthT any,
+] struct {
+	First FirstT
+	Second SecondT
+	Third ThirdT
+	Fourth FourthT
+	Fifth FifthT
+	Sixth SixthT
+	Seventh SeventhT
+	Eighth EighthT
+}
+
+func MakeTuple8[
+	FirstT any,
+	SecondT any,
+	ThirdT any,
+	FourthT any,
+	FifthT any,
+	SixthT any,
+	SeventhT any,
+	EighthT any,
+](
+	first FirstT,
+	second SecondT,
+	third ThirdT,
+	fourth FourthT,
+	fifth FifthT,
+	sixth SixthT,
+	seventh SeventhT,
+	eighth EighthT,
+) Tuple8[FirstT, SecondT, ThirdT, FourthT, FifthT, SixthT, SeventhT, EighthT] {
+	return Tuple8[FirstT, SecondT, ThirdT, FourthT, FifthT, SixthT, SeventhT, EighthT] {
+		First: first,
+		Second: second,
+		Third: third,
+		Fourth: fourth,
+		Fifth: fifth,
+		Sixth: sixth,
+		Seventh: seventh,
+		Eighth: eighth,
+	}
+}